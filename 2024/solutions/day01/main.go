@@ -60,14 +60,12 @@ func part1() {
 func part2() {
 	list1, list2 := parse(input)
 
-	mults := make(map[int]int)
-	for _, val := range list2 {
-		mults[val] += 1
-	}
+	mults := util.NewCounter[int]()
+	mults.AddAll(list2)
 
 	score := 0
 	for _, val := range list1 {
-		score += (val * mults[val])
+		score += (val * mults.Get(val))
 	}
 
 	fmt.Println("total similarity score:", score)
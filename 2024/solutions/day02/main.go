@@ -23,13 +23,6 @@ func count[T any](list []T, pred func(T) bool) int {
 	return count
 }
 
-func remove(orig []int, i int) []int {
-	res := make([]int, len(orig)-1)
-	copy(res, orig[:i])
-	copy(res[i:], orig[i+1:])
-	return res
-}
-
 func parse(input string) [][]int {
 	reports := [][]int{}
 
@@ -88,12 +81,15 @@ func isSafeWithDampener(report []int) bool {
 	//   * example: 8 6 3 5 4 forms V shape with (6 3 5) and we need to remove the 3
 	//   * example: 8 6 3 7 2 forms V shape with (6 3 7) and we need to remove the 7
 	// something should be possible along the lines of choosing the "best" value to keep out of the trio
-	isSafe, i := isSafe(report)
-	if isSafe {
+	if isSafeBool(report) {
 		return true
-	} else {
-		return isSafeBool(remove(report, i-1)) || isSafeBool(remove(report, i)) || isSafeBool(remove(report, 0))
 	}
+	for _, dampened := range util.EachRemoved(report) {
+		if isSafeBool(dampened) {
+			return true
+		}
+	}
+	return false
 }
 
 func part1() {
@@ -13,24 +13,26 @@ import (
 //go:embed input
 var input string
 
-var directions [][]int = buildDirections(false)
-var diagonalDirections [][]int = buildDirections(true)
+type Vec2 = util.Vec2[int]
 
-func buildDirections(onlyDiagonals bool) [][]int {
-	var directions [][]int
+var directions []Vec2 = buildDirections(false)
+var diagonalDirections []Vec2 = buildDirections(true)
+
+func buildDirections(onlyDiagonals bool) []Vec2 {
+	var directions []Vec2
 	for dx := -1; dx <= 1; dx++ {
 		for dy := -1; dy <= 1; dy++ {
 			if (dx == 0 && dy == 0) || (onlyDiagonals && (dx == 0 || dy == 0)) {
 				continue
 			}
-			directions = append(directions, []int{dx, dy})
+			directions = append(directions, Vec2{dx, dy})
 		}
 	}
 	return directions
 }
 
-func parse(input string) [][]rune {
-	var result [][]rune
+func parse(input string) util.Grid[rune] {
+	var result util.Grid[rune]
 	scanner := bufio.NewScanner(strings.NewReader(input))
 	for scanner.Scan() {
 		line := scanner.Text()
@@ -40,24 +42,22 @@ func parse(input string) [][]rune {
 }
 
 // returns true if there is a match starting at the given position, going in the given direction
-func matchesDirection(grid [][]rune, target string, i int, j int, dir []int) bool {
-	di, dj := dir[0], dir[1]
-	for d := range target {
-		ni := i + d*di
-		nj := j + d*dj
-
-		if ni < 0 || ni >= len(grid) || nj < 0 || nj >= len(grid[0]) || grid[ni][nj] != rune(target[d]) {
+func matchesDirection(grid util.Grid[rune], target string, pos Vec2, dir Vec2) bool {
+	d := 0
+	for loc := range util.RayN(pos, dir, len(target)) {
+		if !grid.InBounds(loc) || grid.Get(loc) != rune(target[d]) {
 			return false
 		}
+		d++
 	}
 	return true
 }
 
 // returns: valid matches starting at the given position, as a list of (i, j, di, dj) pairs
-func matches(grid [][]rune, target string, i int, j int, directions [][]int) [][]int {
+func matches(grid util.Grid[rune], target string, i int, j int, directions []Vec2) [][]int {
 	var matches [][]int
 	for _, dir := range directions {
-		if matchesDirection(grid, target, i, j, dir) {
+		if matchesDirection(grid, target, Vec2{i, j}, dir) {
 			matches = append(matches, []int{i, j, dir[0], dir[1]})
 		}
 	}
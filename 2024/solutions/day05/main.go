@@ -1,9 +1,7 @@
 package main
 
 import (
-	"bufio"
 	"fmt"
-	"slices"
 	"strings"
 
 	_ "embed"
@@ -20,72 +18,32 @@ type PuzzleInput struct {
 }
 
 func parse(input string) PuzzleInput {
-	var rules [][2]int
-	var updates [][]int
-
-	doneRules := false
-	scanner := bufio.NewScanner(strings.NewReader(input))
-	for scanner.Scan() {
-		line := scanner.Text()
-		if line == "" {
-			doneRules = true
-		} else {
-			if !doneRules {
-				parts := strings.Split(line, "|")
-				rules = append(rules, [2]int{util.MustAtoi(parts[0]), util.MustAtoi(parts[1])})
-			} else {
-				parts := strings.Split(line, ",")
-				update := make([]int, len(parts))
-				for i := range parts {
-					update[i] = util.MustAtoi(parts[i])
-				}
-				updates = append(updates, update)
-			}
-		}
-	}
+	sections := util.Sections(input)
+	rules := util.ParseLines(sections[0], func(line string) [2]int {
+		parts := strings.Split(line, "|")
+		return [2]int{util.MustAtoi(parts[0]), util.MustAtoi(parts[1])}
+	})
+	updates := util.ParseLines(sections[1], util.Ints)
 
 	return PuzzleInput{rules, updates}
 }
 
-/*
-example: given rule 123|456, the map will contain 456 -> [123] meaning that
-we should not ever see page 123 after page 456
-
-i.e. maps each number to numbers that it must appear after
-*/
-func (p PuzzleInput) predecessors() map[int]util.Set[int] {
-	res := make(map[int]util.Set[int])
+// graph with an edge x -> y for every rule x|y, i.e. x must be printed before y
+func (p PuzzleInput) ruleGraph() *util.Digraph[int] {
+	g := util.NewDigraph[int]()
 	for _, rule := range p.rules {
-		x, y := rule[0], rule[1]
-		if _, ok := res[y]; !ok {
-			res[y] = util.CreateSet[int]()
-		}
-		res[y].Add(x)
+		g.AddEdge(rule[0], rule[1])
 	}
-	return res
-}
-
-func isCorrectlyOrdered(update []int, invalidSuccessors map[int]util.Set[int]) bool {
-	// set of values that are illegal to see at a given point
-	illegal := util.CreateSet[int]()
-	for _, val := range update {
-		if illegal.Has(val) {
-			return false
-		}
-		for n := range invalidSuccessors[val] {
-			illegal.Add(n)
-		}
-	}
-	return true
+	return g
 }
 
 func part1() {
 	problem := parse(input)
-	invalid := problem.predecessors()
+	rules := problem.ruleGraph()
 
 	total := 0
 	for _, update := range problem.updates {
-		if isCorrectlyOrdered(update, invalid) {
+		if len(rules.Violations(update)) == 0 {
 			//fmt.Println("correctly ordered:", update)
 			total += update[len(update)/2]
 		}
@@ -95,22 +53,19 @@ func part1() {
 
 func part2() {
 	problem := parse(input)
-	predecessors := problem.predecessors()
+	rules := problem.ruleGraph()
 
 	total := 0
 	for _, update := range problem.updates {
-		sortedUpdate := slices.SortedFunc(slices.Values(update), func(a, b int) int {
-			if predecessors[a].Has(b) {
-				return 1
-			} else if predecessors[b].Has(a) {
-				return -1
-			} else {
-				return 0
-			}
-		})
-		if !slices.Equal(update, sortedUpdate) {
-			total += sortedUpdate[len(sortedUpdate)/2]
+		if len(rules.Violations(update)) == 0 {
+			continue
+		}
+		// the full rule set can be cyclic, only the rules between pages in this update need to be consistent
+		sortedUpdate, err := rules.Restrict(update).TopoSort()
+		if err != nil {
+			panic(err)
 		}
+		total += sortedUpdate[len(sortedUpdate)/2]
 	}
 	fmt.Println("total:", total)
 }
@@ -1,9 +1,7 @@
 package main
 
 import (
-	"bufio"
 	"fmt"
-	"strings"
 
 	_ "embed"
 
@@ -13,40 +11,23 @@ import (
 //go:embed input
 var input string
 
-type PuzzleInput struct {
-	grid     [][]Tile
-	startPos [2]int
-}
+type Vec2 = util.Vec2[int]
 
-func (i PuzzleInput) InBounds(pos [2]int) bool {
-	return pos[0] >= 0 && pos[0] < len(i.grid) && pos[1] >= 0 && pos[1] < len(i.grid[0])
-}
-
-func (i PuzzleInput) Get(pos [2]int) Tile {
-	return i.grid[pos[0]][pos[1]]
-}
-
-func (i PuzzleInput) Set(pos [2]int, val Tile) {
-	i.grid[pos[0]][pos[1]] = val
+type PuzzleInput struct {
+	grid     util.Grid[Tile]
+	startPos Vec2
 }
 
 func Parse(input string) PuzzleInput {
-	var grid [][]Tile
-	var startPos [2]int
-
-	scanner := bufio.NewScanner(strings.NewReader(input))
-	for i := 0; scanner.Scan(); i++ {
-		line := scanner.Text()
-		tileLine := make([]Tile, len(line))
-		for j := range line {
-			tileLine[j] = MatchTile(line[j])
-			if tileLine[j] == StartPos {
-				startPos = [2]int{i, j}
-			}
+	var p PuzzleInput
+	p.grid = util.NewGridFromString(input, func(r rune, pos Vec2) Tile {
+		tile := MatchTile(byte(r))
+		if tile == StartPos {
+			p.startPos = pos
 		}
-		grid = append(grid, tileLine)
-	}
-	return PuzzleInput{grid, startPos}
+		return tile
+	})
+	return p
 }
 
 type Tile int
@@ -68,57 +49,59 @@ func MatchTile(ch byte) Tile {
 	}
 }
 
-func move(pos [2]int, dir complex64) [2]int {
-	return [2]int{pos[0] + int(real(dir)), pos[1] + int(imag(dir))}
+func (i PuzzleInput) NewVisitedSet() *util.GridBitSet {
+	return util.NewGridBitSet(len(i.grid), len(i.grid[0]), util.Directions4)
 }
 
-// returns: (set of positions visited), (if the walker looped)
-func walk(problem PuzzleInput) (map[[2]int]util.Set[complex64], bool) {
-	visited := make(map[[2]int]util.Set[complex64])
+// walks the guard, recording each (position, direction) visited in the given set after clearing it
+// returns: if the walker looped
+func walk(problem PuzzleInput, visited *util.GridBitSet) bool {
+	visited.Reset()
 
-	// represent orientation as complex number w/ real part in row-axis and imaginary part in col-axis
-	// i.e. "up" -> reduce row by 1 -> -1
+	// directions are (row, col) steps, i.e. "up" -> reduce row by 1
+	grid := problem.grid
 	pos := problem.startPos
-	var direction complex64 = -1
-	for problem.InBounds(pos) && !visited[pos].Has(direction) {
-		if _, ok := visited[pos]; !ok {
-			visited[pos] = make(util.Set[complex64])
-		}
-		visited[pos].Add(direction)
+	direction := Vec2{-1, 0}
+	for grid.InBounds(pos) && !visited.TestState(pos, direction) {
+		visited.SetState(pos, direction)
 
-		nextPos := move(pos, direction)
-		if problem.InBounds(nextPos) && problem.Get(nextPos) == Obstacle {
-			direction *= -1i
+		nextPos := pos.Add(direction)
+		if grid.InBounds(nextPos) && grid.Get(nextPos) == Obstacle {
+			direction = direction.Rotate90CW()
 		} else {
 			pos = nextPos
 		}
 
 	}
 
-	return visited, problem.InBounds(pos)
+	return grid.InBounds(pos)
 }
 
 func part1() {
 	problem := Parse(input)
-	path, _ := walk(problem)
-	fmt.Println("answer:", len(path))
+	path := problem.NewVisitedSet()
+	walk(problem, path)
+	fmt.Println("answer:", path.CountPositions())
 }
 
 func part2() {
 	problem := Parse(input)
-	path, _ := walk(problem)
+	path := problem.NewVisitedSet()
+	walk(problem, path)
 
+	// reuse one set across all the trial walks instead of allocating each time
+	visited := problem.NewVisitedSet()
 	count := 0
-	for pos := range path {
+	for pos := range path.Positions() {
 		// check if rotating at this point would have resulted in a loop
-		if problem.Get(pos) == StartPos {
+		if problem.grid.Get(pos) == StartPos {
 			continue
 		}
-		problem.Set(pos, Obstacle)
-		if _, wouldLoop := walk(problem); wouldLoop {
+		problem.grid.Set(pos, Obstacle)
+		if wouldLoop := walk(problem, visited); wouldLoop {
 			count++
 		}
-		problem.Set(pos, Empty)
+		problem.grid.Set(pos, Empty)
 	}
 
 	fmt.Println("answer:", count)
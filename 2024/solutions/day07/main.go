@@ -3,7 +3,6 @@ package main
 import (
 	"bufio"
 	"fmt"
-	"strconv"
 	"strings"
 
 	_ "embed"
@@ -22,23 +21,8 @@ func Parse(input string) PuzzleInput {
 	var problem PuzzleInput
 	scanner := bufio.NewScanner(strings.NewReader(input))
 	for scanner.Scan() {
-		line := scanner.Text()
-		var equation Equation
-
-		for i, num := range strings.Fields(line) {
-			if i == 0 {
-				// strip the colon from the end of the target number, then parse as int64
-				parsedNum, err := strconv.ParseInt(num[:len(num)-1], 10, 64)
-				if err != nil {
-					panic(err)
-				}
-				equation.target = parsedNum
-			} else {
-				equation.vals = append(equation.vals, int64(util.MustAtoi(num)))
-			}
-		}
-
-		problem.equations = append(problem.equations, equation)
+		nums := util.Int64s(scanner.Text())
+		problem.equations = append(problem.equations, Equation{target: nums[0], vals: nums[1:]})
 	}
 	return problem
 }
@@ -48,52 +32,40 @@ type Equation struct {
 	vals   []int64
 }
 
+// result of applying some sequence of operators to vals[:nextIndex]
+type searchState struct {
+	partialResult int64
+	nextIndex     int
+}
+
 func (e Equation) isSatisfiable(allowConcatenation bool) bool {
-	return e.isSatisfiableHelper(allowConcatenation, e.vals[0], 1)
+	// different operator prefixes can reach the same partial result, so memoize by state
+	satisfiable := util.NewMemo(0, func(recurse func(searchState) bool, s searchState) bool {
+		return e.isSatisfiableHelper(allowConcatenation, recurse, s)
+	})
+	return satisfiable.Get(searchState{e.vals[0], 1})
 }
 
-func (e Equation) isSatisfiableHelper(allowConcatenation bool, partialResult int64, nextIndex int) bool {
+func (e Equation) isSatisfiableHelper(allowConcatenation bool, recurse func(searchState) bool, s searchState) bool {
 	// key observation: all vals are positive and only *, + operations are allowed, so
 	// our partial sum can only increase as we use more values
-	if partialResult > e.target {
+	if s.partialResult > e.target {
 		return false
 	}
-	if nextIndex == len(e.vals) {
-		return partialResult == e.target
+	if s.nextIndex == len(e.vals) {
+		return s.partialResult == e.target
 	}
 
-	satisfiable := e.isSatisfiableHelper(allowConcatenation, partialResult+e.vals[nextIndex], nextIndex+1) ||
-		e.isSatisfiableHelper(allowConcatenation, partialResult*e.vals[nextIndex], nextIndex+1)
+	next := e.vals[s.nextIndex]
+	satisfiable := recurse(searchState{s.partialResult + next, s.nextIndex + 1}) ||
+		recurse(searchState{s.partialResult * next, s.nextIndex + 1})
 	if allowConcatenation {
-		concatenated := concatenate(partialResult, e.vals[nextIndex])
-		satisfiable = satisfiable || e.isSatisfiableHelper(allowConcatenation, concatenated, nextIndex+1)
+		concatenated := util.Concat(s.partialResult, next)
+		satisfiable = satisfiable || recurse(searchState{concatenated, s.nextIndex + 1})
 	}
 	return satisfiable
 }
 
-func digits(a int64) int {
-	var p int = 0
-	var base int64 = 1
-
-	for base <= a {
-		p++
-		base *= 10
-	}
-
-	// p is the smallest int s.t. 10^p > a
-	return p
-}
-
-// concatenate(123, 456) = 123456
-func concatenate(a int64, b int64) int64 {
-	res := a
-	for range digits(b) {
-		res *= 10
-	}
-
-	return res + b
-}
-
 func part1() {
 	problem := Parse(input)
 	var ans int64
@@ -3,6 +3,7 @@ package main
 import (
 	"bufio"
 	"fmt"
+	"slices"
 	"strings"
 
 	_ "embed"
@@ -10,7 +11,7 @@ import (
 	"github.com/rowantran/advent-of-code/2024/util"
 )
 
-type Vec2 = util.Vec2
+type Vec2 = util.Vec2[int]
 
 //go:embed input
 var input string
@@ -51,13 +52,8 @@ func (p PuzzleInput) Antinodes(p2 bool) util.Set[Vec2] {
 	antinodes := make(util.Set[Vec2])
 
 	for _, antennas := range p.antennas {
-		for i, a1 := range antennas {
-			for j, a2 := range antennas {
-				if i <= j {
-					continue
-				}
-				antinodes.AddAll(p.pairwiseAntinodes(a1, a2, p2))
-			}
+		for a1, a2 := range util.Pairs(antennas) {
+			antinodes.AddAll(p.pairwiseAntinodes(a1, a2, p2))
 		}
 	}
 
@@ -68,30 +64,22 @@ func (p PuzzleInput) pairwiseAntinodes(a1 Vec2, a2 Vec2, p2 bool) []Vec2 {
 	delta := a2.Sub(a1)
 	var antinodes []Vec2
 
-	// try to add a1 + delta*i, a1 + delta*(i + increment), a1 + delta*(i + 2*increment), ...
-	var addNodes = func(i int, increment int) {
-		for anti := a1.Add(delta.Mul(i)); p.IsValidLocation(anti); anti = a1.Add(delta.Mul(i)) {
-			antinodes = append(antinodes, anti)
-			i += increment
-			if increment == 0 {
-				break
-			}
-		}
-	}
-
 	if p2 {
-		// add all points a1 + c*(a2-a1); c is an integer && the resulting point is in the grid
-
-		// input is constructed s.t. dx, dy := (delta[0], delta[1]) are coprime for all pairs of antennas
-		// otherwise, we would need to handle cases where fractional c would result in a valid
-		// coordinate, e.g. (dx,dy)=(2,2) -> a1 + 0.5(a2-a1) = a1 + {1, 1} is a collinear point with int coordinates
-		addNodes(0, 1)
-		addNodes(-1, -1)
+		// add all points collinear with a1 and a2 that are in the grid
+		// if dx, dy aren't coprime then fractional multiples of a2-a1 can still land on integer
+		// coordinates, e.g. (dx,dy)=(2,2) -> a1 + 0.5(a2-a1) = a1 + {1, 1}, so step by the reduced delta
+		step := delta.Reduce()
+		outOfBounds := func(loc Vec2) bool { return !p.IsValidLocation(loc) }
+		antinodes = slices.AppendSeq(antinodes, util.RayUntil(a1, step, outOfBounds))
+		antinodes = slices.AppendSeq(antinodes, util.RayUntil(a1.Sub(step), step.Neg(), outOfBounds))
 	} else {
-		// same as above, but only consider c = -1, 2 because we need the point to be twice as far from
+		// only consider a1 + c*(a2-a1) for c = -1, 2 because we need the point to be twice as far from
 		// one antenna as the other
-		addNodes(-1, 0)
-		addNodes(2, 0)
+		for _, anti := range []Vec2{a1.Sub(delta), a1.Add(delta.Mul(2))} {
+			if p.IsValidLocation(anti) {
+				antinodes = append(antinodes, anti)
+			}
+		}
 	}
 	return antinodes
 }
@@ -40,7 +40,8 @@ func Parse(input string) PuzzleInput {
 	return problem
 }
 
-func (p PuzzleInput) CompactAndChecksum(allowFragmentation bool) int64 {
+// move file blocks one at a time from the end of the disk into the leftmost free space
+func (p PuzzleInput) CompactFragmented() int64 {
 	var ans int64
 
 	// starting at index i, fill in n locations with as much file content as possible,
@@ -56,13 +57,6 @@ func (p PuzzleInput) CompactAndChecksum(allowFragmentation bool) int64 {
 		}
 	}
 
-	var isValidFileForGap = func(gap int, i int) bool {
-		if p.files[i].size == 0 {
-			return false
-		}
-		return allowFragmentation || p.files[i].size <= gap
-	}
-
 	i := 0
 	for f := range p.files {
 		// pop and process next file from the beginning
@@ -76,7 +70,7 @@ func (p PuzzleInput) CompactAndChecksum(allowFragmentation bool) int64 {
 		gap := p.gaps[f]
 		for gap > 0 {
 			var j int
-			for j = len(p.files) - 1; j > f && !isValidFileForGap(gap, j); j-- {
+			for j = len(p.files) - 1; j > f && p.files[j].size == 0; j-- {
 			}
 			// if no valid file was found, the rest of the gap will stay empty
 			if j <= f {
@@ -94,20 +88,50 @@ func (p PuzzleInput) CompactAndChecksum(allowFragmentation bool) int64 {
 	return ans
 }
 
+// move whole files, highest id first, into the leftmost free space big enough to hold them
+func (p PuzzleInput) CompactWhole() int64 {
+	free := util.NewRangeSet[int]()
+	starts := make([]int, len(p.files))
+	pos := 0
+	for f, file := range p.files {
+		starts[f] = pos
+		pos += file.size
+		if f < len(p.gaps) {
+			free.Add(util.IntervalOfLen(pos, p.gaps[f]))
+			pos += p.gaps[f]
+		}
+	}
+
+	var ans int64
+	for f := len(p.files) - 1; f >= 0; f-- {
+		size := p.files[f].size
+		// space freed by a moved file is to the right of every file still to move, so it
+		// never needs to go back into the free set
+		if fit, ok := free.FirstFit(size); ok && fit.Lo < starts[f] {
+			starts[f] = fit.Lo
+			free.Remove(fit)
+		}
+		for i := starts[f]; i < starts[f]+size; i++ {
+			ans += int64(i * p.files[f].id)
+		}
+	}
+	return ans
+}
+
 func runeToInt(r rune) int {
 	return int(r - '0')
 }
 
 func part1() {
 	problem := Parse(input)
-	ans := problem.CompactAndChecksum(true)
+	ans := problem.CompactFragmented()
 	//fmt.Println(problem)
 	fmt.Println("answer:", ans)
 }
 
 func part2() {
 	problem := Parse(input)
-	ans := problem.CompactAndChecksum(false)
+	ans := problem.CompactWhole()
 	fmt.Println("answer:", ans)
 }
 
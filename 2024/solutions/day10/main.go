@@ -3,6 +3,7 @@ package main
 import (
 	"bufio"
 	"fmt"
+	"iter"
 	"strings"
 
 	_ "embed"
@@ -10,16 +11,13 @@ import (
 	"github.com/rowantran/advent-of-code/2024/util"
 )
 
-var dirs = []complex64{-1, 1, -1i, 1i}
+type Pos = util.GaussInt
+
+var dirs = []Pos{{-1, 0}, {1, 0}, {0, -1}, {0, 1}}
 
 //go:embed input
 var input string
 
-type PointInfo struct {
-	peaks util.Set[complex64]
-	paths int
-}
-
 type PuzzleInput struct {
 	heights [][]int
 }
@@ -39,56 +37,53 @@ func Parse(input string) PuzzleInput {
 }
 
 func (p PuzzleInput) Solve(p2 bool) int {
-	reachable := make(map[complex64]PointInfo)
-	ans := 0
-
+	var trailheads []Pos
 	for r := range len(p.heights) {
 		for c := range len(p.heights[0]) {
-			pos := util.Pack(r, c)
+			pos := util.NewGaussInt(r, c)
 			if p.getHeight(pos) == 0 {
-				if p2 {
-					ans += p.dfs(reachable, pos).paths
-				} else {
-					ans += p.dfs(reachable, pos).peaks.Size()
-				}
+				trailheads = append(trailheads, pos)
 			}
 		}
 	}
 
-	return ans
-}
+	// trails strictly increase in height, so they form a DAG ending at the peaks
+	isPeak := func(pos Pos) bool { return p.getHeight(pos) == 9 }
+	trails, err := util.CountDAGPaths(trailheads, p.uphill, isPeak, !p2)
+	if err != nil {
+		panic(err)
+	}
 
-func (p PuzzleInput) dfs(reachable map[complex64]PointInfo, pos complex64) PointInfo {
-	if val, ok := reachable[pos]; ok {
-		return val
+	ans := 0
+	for _, pos := range trailheads {
+		if p2 {
+			ans += trails.Paths[pos]
+		} else {
+			ans += trails.Reachable[pos].Count()
+		}
 	}
+	return ans
+}
 
-	ans := PointInfo{peaks: make(util.Set[complex64])}
-	if p.getHeight(pos) == 9 {
-		ans.peaks.Add(pos)
-		ans.paths = 1
-	} else {
+// neighboring positions exactly one step higher than pos
+func (p PuzzleInput) uphill(pos Pos) iter.Seq[Pos] {
+	return func(yield func(Pos) bool) {
 		for _, dir := range dirs {
-			if p.isInGrid(pos+dir) && p.getHeight(pos)+1 == p.getHeight(pos+dir) {
-				res := p.dfs(reachable, pos+dir)
-				for peak := range res.peaks {
-					ans.peaks.Add(peak)
-				}
-				ans.paths += res.paths
+			next := pos.Add(dir)
+			if p.isInGrid(next) && p.getHeight(pos)+1 == p.getHeight(next) && !yield(next) {
+				return
 			}
 		}
 	}
-	reachable[pos] = ans
-	return ans
 }
 
-func (p PuzzleInput) getHeight(pos complex64) int {
-	row, col := util.Unpack(pos)
+func (p PuzzleInput) getHeight(pos Pos) int {
+	row, col := pos.Parts()
 	return p.heights[row][col]
 }
 
-func (p PuzzleInput) isInGrid(pos complex64) bool {
-	row, col := util.Unpack(pos)
+func (p PuzzleInput) isInGrid(pos Pos) bool {
+	row, col := pos.Parts()
 	return row >= 0 && row < len(p.heights) && col >= 0 && col < len(p.heights[0])
 }
 
@@ -13,61 +13,44 @@ import (
 var input string
 
 type PuzzleInput struct {
-	counts map[int64]int
+	counts util.Counter[int64]
 }
 
 func Parse(input string) PuzzleInput {
 	var problem PuzzleInput
-	problem.counts = make(map[int64]int)
+	problem.counts = util.NewCounter[int64]()
 	for _, num := range strings.Fields(input) {
 		parsedNum := util.MustAtoiInt64(num)
-		problem.counts[parsedNum]++
+		problem.counts.Add(parsedNum, 1)
 	}
 	return problem
 }
 
 func solve(p PuzzleInput, iterations int) int {
+	// stone transformations are "simultaneous" so we can't mutate the counts in-place,
+	// instead alternate between two counters
+	counts, next := p.counts, util.NewCounter[int64]()
 	for range iterations {
-		// stone transformations are "simultaneous" so we can't mutate the counts map in-place
-		deltas := make(map[int64]int)
+		counts.TransformInto(next, blink)
+		counts, next = next, counts
 
-		for k, v := range p.counts {
-			deltas[k] -= v
-
-			switch {
-			case k == 0:
-				deltas[1] += v
-			case util.DigitCountInt64(k)%2 == 0:
-				left, right := splitNum(k)
-				deltas[left] += v
-				deltas[right] += v
-			default:
-				deltas[2024*k] += v
-			}
-		}
-
-		for k, v := range deltas {
-			p.counts[k] += v
-			if p.counts[k] == 0 {
-				delete(p.counts, k)
-			}
-		}
-
-		//fmt.Printf("completed iteration #%d: %v\n", i+1, p.counts)
+		//fmt.Printf("completed iteration #%d: %v\n", i+1, counts)
 	}
 
-	sum := 0
-	for _, v := range p.counts {
-		sum += v
-	}
-	return sum
+	return counts.Total()
 }
 
-// split an integer with even number of digits into left and right halves
-func splitNum(n int64) (int64, int64) {
-	digits := util.DigitCountInt64(n)
-	div := util.ExpInt64(10, digits/2)
-	return n / div, n % div
+// the stones that a single stone turns into after one blink
+func blink(k int64, emit func(int64)) {
+	switch left, right, evenDigits := util.SplitHalf(k); {
+	case k == 0:
+		emit(1)
+	case evenDigits:
+		emit(left)
+		emit(right)
+	default:
+		emit(util.MustMul(2024, k))
+	}
 }
 
 func part1() {
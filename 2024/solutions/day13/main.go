@@ -1,7 +1,6 @@
 package main
 
 import (
-	"bufio"
 	"strings"
 
 	_ "embed"
@@ -25,51 +24,37 @@ type PuzzleInput struct {
 
 func Parse(input string, isPart2 bool) PuzzleInput {
 	var problem PuzzleInput
-	scanner := bufio.NewScanner(strings.NewReader(input))
+	problem.machines = util.ParseSections(input, func(s util.Section) MachineInfo {
+		return parseMachine(s, isPart2)
+	})
+	return problem
+}
 
+func parseMachine(s util.Section, isPart2 bool) MachineInfo {
 	var machine MachineInfo
-	for scanner.Scan() {
-		line := scanner.Text()
-		if len(line) == 0 {
-			problem.machines = append(problem.machines, machine)
-			continue
-		}
-
-		fields := strings.Fields(line)
-		xField, yField := fields[len(fields)-2], fields[len(fields)-1]
-		vec := Vec2{util.MustAtoiInt64(xField[2 : len(xField)-1]), util.MustAtoiInt64(yField[2:])}
-
-		switch len(fields) {
-		case 3:
-			// prize
+	for _, line := range s {
+		if strings.HasPrefix(line, "Prize") {
+			c := util.MustScan(line, "Prize: X={int}, Y={int}")
+			vec := Vec2{c.Int64(0), c.Int64(1)}
 			if isPart2 {
 				vec = vec.Add(Vec2{part2_offset, part2_offset})
 			}
 			machine.prize = vec
-		case 4:
-			// button
-			buttonType := fields[1][0]
-			if buttonType == 'A' {
+		} else {
+			c := util.MustScan(line, "Button {}: X+{int}, Y+{int}")
+			vec := Vec2{c.Int64(1), c.Int64(2)}
+			if c.Str(0) == "A" {
 				machine.buttonA = vec
 			} else {
 				machine.buttonB = vec
 			}
 		}
 	}
-	// last machine won't be flushed by a blank line
-	problem.machines = append(problem.machines, machine)
-
-	return problem
+	return machine
 }
 
-/*
-The problem suggests a matrix equation, but since the problem (at least part 1) has a restriction
-that the button counts will be <=100, it's easier to just bruteforce
-
-part 2 is not in fact brute-forceable, but luckily all test cases given have unique solutions in R2,
-so we just need to check if the solution has integer parts and not think too hard about the case
-where the matrix is singular
-*/
+// each machine is the system x*buttonA + y*buttonB = prize, which we solve exactly, including
+// machines whose buttons are parallel and so have many solutions to choose the cheapest from
 func solve(p PuzzleInput) int64 {
 	total := int64(0)
 	for _, machine := range p.machines {
@@ -83,37 +68,13 @@ func solve(p PuzzleInput) int64 {
 
 // returns minimum tokens needed to win a prize, or nil if impossible
 func machineCost(m MachineInfo) *int64 {
-	a0, a1 := m.buttonA.Parts()
-	b0, b1 := m.buttonB.Parts()
-	t0, t1 := m.prize.Parts()
-
-	xNum := (t1*b0 - t0*b1)
-	xDen := (a1*b0 - a0*b1)
-	if xDen == 0 {
-		// xDen = bc-ad = -det A, so if xDen = 0 then det A = 0 and A is singular
-		// the test case doesn't have any singular matrices so we don't need to handle this
-		return nil
-	} else if xNum%xDen != 0 {
-		// non-integer solution
-		return nil
-	}
-	x := xNum / xDen
-
-	yNum := (t0 - x*a0)
-	yDen := b0
-	if yDen == 0 {
-		// not sure if this case represents something special, but there is
-		// still a unique solution so we can find y by back-substituting x into
-		// the second equation, i.e. calculate y := (t1 - x*a1) / b1
-		return nil
-	} else if yNum%yDen != 0 {
-		// non-integer solution
+	x, y, ok := util.MinCostNonNegative2(m.buttonA, m.buttonB, m.prize, 3, 1)
+	if !ok {
 		return nil
 	}
-	y := yNum / yDen
 
 	res := new(int64)
-	*res = 3*x + y
+	*res = util.MustAdd(util.MustMul(3, x), y)
 	return res
 }
 
@@ -23,9 +23,8 @@ type Robot struct {
 }
 
 func ParseRobot(line string) Robot {
-	fields := strings.Fields(line)
-	// strip off the initial "p=" or "v=" from the two fields, then convert to vec2
-	return Robot{util.NewVec2Int(fields[0][2:]), util.NewVec2Int(fields[1][2:])}
+	c := util.MustScan(line, "p={int},{int} v={int},{int}")
+	return Robot{Vec2{c.Int(0), c.Int(1)}, Vec2{c.Int(2), c.Int(3)}}
 }
 
 func (r Robot) FinalQuadrant(seconds int) ([2]bool, error) {
@@ -39,15 +38,11 @@ func (r Robot) FinalQuadrant(seconds int) ([2]bool, error) {
 
 func (r Robot) FinalPos(seconds int) Vec2 {
 	unwrappedPos := r.startPos.Add(r.velocity.Mul(seconds))
-	finalPos := Vec2{mod(unwrappedPos[0], width), mod(unwrappedPos[1], height)}
+	finalPos := unwrappedPos.Mod(Vec2{width, height})
 	//fmt.Printf("final pos for robot with startPos %v, vel %v = %v\n", r.startPos, r.velocity, finalPos)
 	return finalPos
 }
 
-func mod(a int, b int) int {
-	return ((a % b) + b) % b
-}
-
 type PuzzleInput struct {
 	robots []Robot
 }
@@ -146,7 +141,7 @@ func writeAllImages(p PuzzleInput) {
 		image.WriteImageToFile()
 	}
 
-	image.seconds = t
+	image.seconds = treeTime()
 	image.WriteImageToFile()
 }
 
@@ -155,7 +150,6 @@ var input string
 
 // const width, height = 11, 7
 const width, height = 101, 103
-const t = 7861
 
 // results from investigating part 2 images:
 // image_0033 has dots clustered in rows
@@ -163,7 +157,14 @@ const t = 7861
 // using insight from reddit, find the desired time t such that
 // t = 33 mod 103
 // t = 84 mod 101
-// CRT calculator shows t = 7861
+// which gives t = 7861
+func treeTime() int {
+	t, _, err := util.CRT([]int{33, 84}, []int{height, width})
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
 
 func main() {
 	util.SolveChosenPart(func(isPart2 bool) int64 {
@@ -1,9 +1,7 @@
 package main
 
 import (
-	"bufio"
 	"fmt"
-	"strings"
 
 	_ "embed"
 
@@ -44,12 +42,10 @@ type PuzzleInput struct {
 
 func Parse(input string, isPart2 bool) PuzzleInput {
 	var problem PuzzleInput
-	parts := strings.Split(input, "\n\n")
+	parts := util.Sections(input)
 
 	if isPart2 {
-		scanner := bufio.NewScanner(strings.NewReader(parts[0]))
-		for scanner.Scan() {
-			line := scanner.Text()
+		for _, line := range parts[0] {
 			row := make([]Tile, 2*len(line))
 			for i, r := range line {
 				row[i*2] = runeToTile[r]
@@ -66,7 +62,7 @@ func Parse(input string, isPart2 bool) PuzzleInput {
 			problem.grid = append(problem.grid, row)
 		}
 	} else {
-		problem.grid = util.NewGridFromString(parts[0], func(r rune, pos Vec2) Tile {
+		problem.grid = util.NewGridFromString(parts[0].Text(), func(r rune, pos Vec2) Tile {
 			if runeToTile[r] == Robot {
 				problem.robotPos = pos
 			}
@@ -74,9 +70,7 @@ func Parse(input string, isPart2 bool) PuzzleInput {
 		})
 	}
 
-	scan := bufio.NewScanner(strings.NewReader(parts[1]))
-	for scan.Scan() {
-		line := scan.Text()
+	for _, line := range parts[1] {
 		problem.moves = append(problem.moves, []rune(line)...)
 	}
 
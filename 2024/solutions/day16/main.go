@@ -1,9 +1,6 @@
 package main
 
 import (
-	"container/heap"
-	"math"
-
 	_ "embed"
 
 	"github.com/rowantran/advent-of-code/2024/util"
@@ -32,121 +29,55 @@ func Parse(input string) PuzzleInput {
 	return p
 }
 
-func (p PuzzleInput) Neighbors(pos Vec2) []Vec2 {
-	neighbors := make([]Vec2, 0)
-	for _, dir := range directions {
-		n := pos.Add(dir)
-		if p.maze.InBounds(n) && p.maze.Get(n) != '#' {
-			neighbors = append(neighbors, n)
-		}
-	}
-	return neighbors
-}
-
 type PuzzleNode struct {
 	location  Vec2
 	direction Vec2
 }
 
-type PuzzleNodeHeapItem struct {
-	node     PuzzleNode
-	distance int
-}
-
-func solve(p PuzzleInput, isPart2 bool) int64 {
-	dists, prevs := dijkstra(p)
-
-	minDistance := math.MaxInt
-	endNodes := []PuzzleNode{}
-	for _, dir := range directions {
-		node := PuzzleNode{p.end, dir}
-		if dists[node] < minDistance {
-			minDistance = dists[node]
-			endNodes = []PuzzleNode{node}
-		} else if dists[node] == minDistance {
-			endNodes = append(endNodes, node)
+// search graph over (location, facing) states: moving forward costs 1 and a 90 degree turn costs 1000
+func (p PuzzleInput) Graph() util.GridGraph[rune, PuzzleNode] {
+	return util.NewGridGraph(p.maze, func(maze util.Grid[rune], node PuzzleNode, emit func(PuzzleNode, int)) {
+		forward := node.location.Add(node.direction)
+		if maze.InBounds(forward) && maze.Get(forward) != '#' {
+			emit(PuzzleNode{forward, node.direction}, 1)
 		}
-	}
-
-	if !isPart2 {
-		return int64(minDistance)
-	} else {
-		tiles := make(util.Set[Vec2])
-		for _, node := range endNodes {
-			tracePaths(prevs, node, tiles)
+		for _, dir := range directions {
+			if node.direction != dir && node.direction != dir.Neg() {
+				emit(PuzzleNode{node.location, dir}, 1000)
+			}
 		}
-		return int64(tiles.Size())
-	}
+	}, func(node PuzzleNode) bool {
+		return node.location == p.end
+	})
 }
 
-// collect all tiles on path(s) to the target node
-func tracePaths(prevs map[PuzzleNode][]PuzzleNode, node PuzzleNode, tiles util.Set[Vec2]) {
-	tiles.Add(node.location)
-	for _, prev := range prevs[node] {
-		tracePaths(prevs, prev, tiles)
-	}
+// manhattan distance to the end is consistent: forward moves cost 1 and change it by at most 1,
+// and turns don't change it at all
+func (p PuzzleInput) heuristic(node PuzzleNode) int {
+	return node.location.Manhattan(p.end)
 }
 
-func dijkstra(p PuzzleInput) (map[PuzzleNode]int, map[PuzzleNode][]PuzzleNode) {
-	dists := make(map[PuzzleNode]int)
-	prevs := make(map[PuzzleNode][]PuzzleNode)
-	pq := util.NewHeap(func(a, b PuzzleNodeHeapItem) bool { return a.distance < b.distance })
-
-	for r := range len(p.maze) {
-		for c := range len(p.maze[r]) {
-			for _, dir := range directions {
-				if p.maze[r][c] == '#' {
-					continue
-				}
-
-				dist := math.MaxInt
-				if (p.maze[r][c] == 'S' && dir == Vec2{0, 1}) {
-					dist = 0
-				}
-				node := PuzzleNode{Vec2{r, c}, dir}
-				dists[node] = dist
-				heap.Push(&pq, PuzzleNodeHeapItem{node, dist})
-			}
-		}
-	}
-
-	for pq.Len() > 0 {
-		node := heap.Pop(&pq).(PuzzleNodeHeapItem).node
-		neighbors := []PuzzleNode{}
+// the reindeer starts facing east
+func (p PuzzleInput) sources() []PuzzleNode {
+	return []PuzzleNode{{p.start, Vec2{0, 1}}}
+}
 
-		// build list of neighbors
-		forward := node.location.Add(node.direction)
-		if p.maze.InBounds(forward) && p.maze.Get(forward) != '#' {
-			neighbors = append(neighbors, PuzzleNode{forward, node.direction})
-		}
-		for _, dir := range directions {
-			if node.direction != dir && node.direction != dir.Mul(-1) {
-				neighbors = append(neighbors, PuzzleNode{node.location, dir})
-			}
-		}
+func solve(p PuzzleInput, isPart2 bool) int64 {
+	graph := p.Graph()
+	sources := p.sources()
 
-		// check neighbors
-		for _, neighbor := range neighbors {
-			var edgeCost int
-			if neighbor.direction == node.direction {
-				// forward move
-				edgeCost = 1
-			} else {
-				// 90 degree turn
-				edgeCost = 1000
-			}
-			totalCost := dists[node] + edgeCost
-			if totalCost < dists[neighbor] {
-				pq.Update(PuzzleNodeHeapItem{neighbor, dists[neighbor]}, PuzzleNodeHeapItem{neighbor, totalCost})
-				dists[neighbor] = totalCost
-				prevs[neighbor] = []PuzzleNode{node}
-			} else if totalCost == dists[neighbor] {
-				prevs[neighbor] = append(prevs[neighbor], node)
-			}
+	if !isPart2 {
+		res := util.AStar(sources, graph.Successors, graph.IsGoal, p.heuristic)
+		return int64(res.Dist)
+	} else {
+		paths := util.Dijkstra(sources, graph.Successors, graph.IsGoal, true)
+		// the end may be reachable facing several directions at the same minimum cost
+		tiles := make(util.Set[Vec2])
+		for node := range paths.OnShortestPaths(paths.Goals...) {
+			tiles.Add(node.location)
 		}
+		return int64(tiles.Size())
 	}
-
-	return dists, prevs
 }
 
 //go:embed input
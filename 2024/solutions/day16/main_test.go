@@ -0,0 +1,45 @@
+package main
+
+import (
+	"os"
+	"testing"
+
+	"github.com/rowantran/advent-of-code/2024/util"
+)
+
+func TestHeuristicConsistent(t *testing.T) {
+	for _, name := range []string{"example_input", "example_input_2"} {
+		data, err := os.ReadFile(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		p := Parse(string(data))
+		graph := p.Graph()
+		if err := util.CheckHeuristic(p.sources(), graph.Successors, graph.IsGoal, p.heuristic); err != nil {
+			t.Errorf("%s: %v", name, err)
+		}
+	}
+}
+
+func TestSolveExamples(t *testing.T) {
+	tests := []struct {
+		name         string
+		part1, part2 int64
+	}{
+		{"example_input", 7036, 45},
+		{"example_input_2", 11048, 64},
+	}
+	for _, tt := range tests {
+		data, err := os.ReadFile(tt.name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		p := Parse(string(data))
+		if got := solve(p, false); got != tt.part1 {
+			t.Errorf("%s part 1 = %d, want %d", tt.name, got, tt.part1)
+		}
+		if got := solve(p, true); got != tt.part2 {
+			t.Errorf("%s part 2 = %d, want %d", tt.name, got, tt.part2)
+		}
+	}
+}
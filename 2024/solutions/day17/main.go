@@ -1,7 +1,6 @@
 package main
 
 import (
-	"bufio"
 	"fmt"
 	"strconv"
 	"strings"
@@ -20,21 +19,13 @@ type Computer struct {
 func Parse(input string) Computer {
 	var c Computer
 
-	parts := strings.Split(input, "\n\n")
+	parts := util.Sections(input)
 
-	reg := 0
-	scanner := bufio.NewScanner(strings.NewReader(parts[0]))
-	for scanner.Scan() {
-		fields := strings.Fields(scanner.Text())
-		c.regs[reg] = util.MustAtoi(fields[len(fields)-1])
-		reg++
+	for reg, line := range parts[0] {
+		c.regs[reg] = util.MustScan(line, "Register {}: {int}").Int(1)
 	}
 
-	programNums := strings.Split(strings.Fields(parts[1])[1], ",")
-	c.program = make([]int, len(programNums))
-	for i := range programNums {
-		c.program[i] = util.MustAtoi(programNums[i])
-	}
+	c.program = util.Ints(parts[1].Text())
 
 	c.ip = 0
 
@@ -12,8 +12,6 @@ import (
 
 type Vec2 = util.Vec2[int]
 
-var dirs = []Vec2{{1,0}, {-1,0}, {0,1}, {0,-1}}
-
 type PuzzleInput struct {
 	bytes []Vec2
 }
@@ -40,59 +38,36 @@ func Parse(input string, isPart2 bool) PuzzleInput {
 	for scanner.Scan() {
 		coords := strings.Split(scanner.Text(), ",")
 		x, y := util.MustAtoi(coords[0]), util.MustAtoi(coords[1])
-		p.bytes = append(p.bytes, Vec2{x,y})
+		p.bytes = append(p.bytes, Vec2{x, y})
 	}
 
 	return p
 }
 
-type BfsQueueEntry struct {
-	node Vec2
-	depth int
-}
-
+// shortest number of steps from the top-left to the bottom-right corner, or -1 if blocked
 func bfs(grid util.Grid[bool]) int {
-	visited := make(map[Vec2]bool)
-	queue := []BfsQueueEntry{{Vec2{0,0},0}}
-	for len(queue) > 0 {
-		h := queue[0]
-		queue = queue[1:]
-
-		if (h.node == Vec2{len(grid)-1, len(grid[0])-1}) {
-			return h.depth
-		}
-
-		if visited[h.node] {
-			continue
-		}
-
-		visited[h.node] = true
-		for _, dir := range dirs {
-			neighbor := h.node.Add(dir)
-			if grid.InBounds(neighbor) && !grid.Get(neighbor) {
-				queue = append(queue, BfsQueueEntry{neighbor, h.depth+1})
-			}
-		}
-	}
-
-	return -1
+	exit := Vec2{len(grid) - 1, len(grid[0]) - 1}
+	graph := util.NewGridWalk(grid, func(corrupted bool) bool { return !corrupted }, exit)
+	return util.BFS([]Vec2{{0, 0}}, graph.Neighbors, graph.IsGoal).GoalDist()
 }
 
-func findFirstBlockingByte(p PuzzleInput) Vec2 {
-	// search for the first value where we cannot find an exit using bytes [0, n)
-	// that means blocker n-1 is the first blocking byte
-	lo, hi := 0, len(p.bytes)
-	for lo < hi {
-		mid := (lo+hi)/2
-		success := bfs(p.GenerateGrid(mid)) != -1
-		if success {
-			lo = mid+1
-		} else {
-			hi = mid
-		}
+// first byte that cuts the start off from the exit, or false if the exit stays reachable
+func findFirstBlockingByte(p PuzzleInput) (Vec2, bool) {
+	// drop bytes onto an empty grid in order and find how many it takes to cut the start off
+	// from the exit, i.e. byte n-1 is the first blocking byte
+	grid := p.GenerateGrid(0)
+	exit := Vec2{len(grid) - 1, len(grid[0]) - 1}
+	graph := util.NewGridWalk(grid, func(bool) bool { return true }, exit)
+	n := util.DisconnectStep(grid.Positions(), p.bytes, graph.Neighbors, Vec2{0, 0}, exit)
+
+	switch n {
+	case -1:
+		return Vec2{}, false
+	case 0:
+		// the empty grid always connects its corners
+		panic("start and exit disconnected before any bytes fell")
 	}
-	
-	return p.bytes[lo-1]
+	return p.bytes[n-1], true
 }
 
 func solve(p PuzzleInput, isPart2 bool) int64 {
@@ -100,7 +75,11 @@ func solve(p PuzzleInput, isPart2 bool) int64 {
 		grid := p.GenerateGrid(1024)
 		return int64(bfs(grid))
 	} else {
-		fmt.Println(findFirstBlockingByte(p))
+		b, ok := findFirstBlockingByte(p)
+		if !ok {
+			panic("no byte blocks the exit")
+		}
+		fmt.Println(b)
 		return 0
 	}
 }
@@ -0,0 +1,94 @@
+package util
+
+import (
+	"fmt"
+	"slices"
+)
+
+// result of an A* search
+type AStarResult[S comparable] struct {
+	Found bool
+	// the goal reached and its distance from the nearest source
+	Goal S
+	Dist int
+	// states from a source to Goal, inclusive
+	Path []S
+	// number of states popped and expanded, for comparing heuristics
+	Expanded int
+}
+
+// find a shortest path from any source to a goal, using heuristic to estimate the remaining
+// distance from a state. edge costs must be non-negative
+//
+// the result is optimal as long as the heuristic is admissible (never overestimates); an
+// inconsistent heuristic costs re-expansions but not correctness. see CheckHeuristic
+func AStar[S comparable](sources []S, successors SuccessorFunc[S], isGoal func(S) bool, heuristic func(S) int) AStarResult[S] {
+	var res AStarResult[S]
+	dist := make(map[S]int)
+	prev := make(map[S]S)
+
+	pq := NewMinPriorityQueue[S, int]()
+	for _, s := range sources {
+		dist[s] = 0
+		pq.Push(s, heuristic(s))
+	}
+
+	for pq.Len() > 0 {
+		s, _ := pq.Pop()
+		res.Expanded++
+
+		if isGoal(s) {
+			res.Found, res.Goal, res.Dist = true, s, dist[s]
+			res.Path = []S{s}
+			for p, ok := prev[s]; ok; p, ok = prev[p] {
+				res.Path = append(res.Path, p)
+			}
+			slices.Reverse(res.Path)
+			return res
+		}
+
+		for next, cost := range successors(s) {
+			total := dist[s] + cost
+			if best, seen := dist[next]; seen && total >= best {
+				continue
+			}
+			// an expanded state only improves if the heuristic is inconsistent, in which case
+			// pushing it again reopens it
+			dist[next] = total
+			prev[next] = s
+			pq.Push(next, total+heuristic(next))
+		}
+	}
+
+	return res
+}
+
+// check that heuristic is consistent over every state reachable from sources, i.e.
+// h(s) <= cost + h(next) for every edge and h(goal) == 0. consistency implies admissibility
+//
+// this visits the whole reachable graph, so it's meant for tests and debugging
+func CheckHeuristic[S comparable](sources []S, successors SuccessorFunc[S], isGoal func(S) bool, heuristic func(S) int) error {
+	visited := make(Set[S])
+	stack := slices.Clone(sources)
+	visited.AddAll(sources)
+
+	for len(stack) > 0 {
+		s := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+
+		h := heuristic(s)
+		if isGoal(s) && h != 0 {
+			return fmt.Errorf("heuristic is %d at goal %v, expected 0", h, s)
+		}
+		for next, cost := range successors(s) {
+			if hNext := heuristic(next); h > cost+hNext {
+				return fmt.Errorf("heuristic is inconsistent on edge %v -> %v: h = %d > cost %d + h = %d", s, next, h, cost, hNext)
+			}
+			if !visited.Has(next) {
+				visited.Add(next)
+				stack = append(stack, next)
+			}
+		}
+	}
+	return nil
+}
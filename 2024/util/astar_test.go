@@ -0,0 +1,75 @@
+package util
+
+import (
+	"strings"
+	"testing"
+)
+
+// grid graph over open cells of a maze, with unit-cost moves
+func mazeGraph(maze string) (GridGraph[rune, Vec2[int]], Vec2[int], Vec2[int]) {
+	var start, end Vec2[int]
+	g := NewGridFromString(strings.TrimSpace(maze), func(r rune, pos Vec2[int]) rune {
+		switch r {
+		case 'S':
+			start = pos
+		case 'E':
+			end = pos
+		}
+		return r
+	})
+	graph := NewGridWalk(g, func(r rune) bool { return r != '#' }, end)
+	return graph, start, end
+}
+
+const testMaze = `
+#######
+#S..#.#
+#.#.#.#
+#.#...#
+#...#E#
+#######
+`
+
+func TestAStarMatchesDijkstra(t *testing.T) {
+	graph, start, end := mazeGraph(testMaze)
+	manhattan := func(pos Vec2[int]) int { return pos.Manhattan(end) }
+	if err := CheckHeuristic([]Vec2[int]{start}, graph.Successors, graph.IsGoal, manhattan); err != nil {
+		t.Fatal(err)
+	}
+
+	want := Dijkstra([]Vec2[int]{start}, graph.Successors, graph.IsGoal, false).Dist[end]
+	res := AStar([]Vec2[int]{start}, graph.Successors, graph.IsGoal, manhattan)
+	if !res.Found || res.Dist != want {
+		t.Fatalf("AStar found %v at %d, want %d", res.Found, res.Dist, want)
+	}
+	if len(res.Path) != res.Dist+1 || res.Path[0] != start || res.Path[len(res.Path)-1] != end {
+		t.Errorf("bad path %v", res.Path)
+	}
+}
+
+func TestCheckHeuristicInconsistent(t *testing.T) {
+	graph, start, end := mazeGraph(testMaze)
+	sources := []Vec2[int]{start}
+
+	// admissible but not consistent: drops by 2 along the unit-cost edges out of start
+	dip := func(pos Vec2[int]) int {
+		if pos == start {
+			return 2
+		}
+		return 0
+	}
+	if err := CheckHeuristic(sources, graph.Successors, graph.IsGoal, dip); err == nil {
+		t.Error("inconsistent heuristic not flagged")
+	}
+
+	nonzeroGoal := func(pos Vec2[int]) int { return pos.Manhattan(end) + 1 }
+	if err := CheckHeuristic(sources, graph.Successors, graph.IsGoal, nonzeroGoal); err == nil {
+		t.Error("heuristic nonzero at goal not flagged")
+	}
+
+	// inconsistency costs re-expansions, not correctness
+	want := Dijkstra(sources, graph.Successors, graph.IsGoal, false).Dist[end]
+	if res := AStar(sources, graph.Successors, graph.IsGoal, dip); res.Dist != want {
+		t.Errorf("AStar with inconsistent heuristic found %d, want %d", res.Dist, want)
+	}
+}
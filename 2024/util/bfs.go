@@ -0,0 +1,73 @@
+package util
+
+import "slices"
+
+// result of a breadth-first search
+type BFSResult[S comparable] struct {
+	// number of steps from the nearest start to each discovered state
+	Dist map[S]int
+	// the state each state was first discovered from; starts have no parent
+	Parent map[S]S
+	// the first goal reached, if the search had a goal
+	Found bool
+	Goal  S
+}
+
+// breadth-first search from starts over unit-cost moves. if isGoal is nil the whole
+// reachable graph is explored, giving the distance from the starts to every state;
+// otherwise the search stops at the nearest goal
+func BFS[S comparable](starts []S, neighbors NeighborFunc[S], isGoal func(S) bool) BFSResult[S] {
+	res := BFSResult[S]{
+		Dist:   make(map[S]int),
+		Parent: make(map[S]S),
+	}
+
+	var queue Queue[S]
+	for _, s := range starts {
+		if _, ok := res.Dist[s]; !ok {
+			res.Dist[s] = 0
+			queue.Push(s)
+		}
+	}
+
+	for queue.Len() > 0 {
+		s := queue.Pop()
+		if isGoal != nil && isGoal(s) {
+			res.Found, res.Goal = true, s
+			return res
+		}
+
+		for next := range neighbors(s) {
+			// mark on enqueue so each state is queued at most once
+			if _, seen := res.Dist[next]; !seen {
+				res.Dist[next] = res.Dist[s] + 1
+				res.Parent[next] = s
+				queue.Push(next)
+			}
+		}
+	}
+
+	return res
+}
+
+// distance from the nearest start to the goal that was found, or -1 if there was none
+func (res BFSResult[S]) GoalDist() int {
+	if !res.Found {
+		return -1
+	}
+	return res.Dist[res.Goal]
+}
+
+// states from the nearest start to target (inclusive), or nil if target wasn't reached
+func (res BFSResult[S]) Path(target S) []S {
+	if _, ok := res.Dist[target]; !ok {
+		return nil
+	}
+
+	path := []S{target}
+	for p, ok := res.Parent[target]; ok; p, ok = res.Parent[p] {
+		path = append(path, p)
+	}
+	slices.Reverse(path)
+	return path
+}
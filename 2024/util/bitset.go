@@ -0,0 +1,182 @@
+package util
+
+import (
+	"fmt"
+	"iter"
+	"math/bits"
+)
+
+// dense set of small non-negative integers
+type BitSet struct {
+	words []uint64
+}
+
+// bitset with room for [0, n), it grows automatically if larger values are set
+func NewBitSet(n int) *BitSet {
+	return &BitSet{words: make([]uint64, (n+63)/64)}
+}
+
+func (b *BitSet) Set(i int) {
+	for i/64 >= len(b.words) {
+		b.words = append(b.words, 0)
+	}
+	b.words[i/64] |= 1 << (i % 64)
+}
+
+func (b *BitSet) Clear(i int) {
+	if i/64 < len(b.words) {
+		b.words[i/64] &^= 1 << (i % 64)
+	}
+}
+
+func (b *BitSet) Test(i int) bool {
+	return i/64 < len(b.words) && b.words[i/64]&(1<<(i%64)) != 0
+}
+
+// unset every bit, keeping the allocated capacity
+func (b *BitSet) Reset() {
+	clear(b.words)
+}
+
+// number of set bits
+func (b *BitSet) Count() int {
+	count := 0
+	for _, w := range b.words {
+		count += bits.OnesCount64(w)
+	}
+	return count
+}
+
+func (b *BitSet) Clone() *BitSet {
+	return &BitSet{words: append([]uint64(nil), b.words...)}
+}
+
+// set every bit that's set in other
+func (b *BitSet) Or(other *BitSet) {
+	for len(b.words) < len(other.words) {
+		b.words = append(b.words, 0)
+	}
+	for i, w := range other.words {
+		b.words[i] |= w
+	}
+}
+
+// clear every bit that isn't set in other
+func (b *BitSet) And(other *BitSet) {
+	for i := range b.words {
+		if i < len(other.words) {
+			b.words[i] &= other.words[i]
+		} else {
+			b.words[i] = 0
+		}
+	}
+}
+
+// clear every bit that's set in other
+func (b *BitSet) AndNot(other *BitSet) {
+	for i := range min(len(b.words), len(other.words)) {
+		b.words[i] &^= other.words[i]
+	}
+}
+
+// iterate over set bits in increasing order
+func (b *BitSet) All() iter.Seq[int] {
+	return func(yield func(int) bool) {
+		for i, w := range b.words {
+			for w != 0 {
+				bit := bits.TrailingZeros64(w)
+				if !yield(i*64 + bit) {
+					return
+				}
+				w &= w - 1
+			}
+		}
+	}
+}
+
+// bitset over (position, direction) states of a rows x cols grid, e.g. for visited tracking
+// in simulations. directions are identified by their index in dirs
+type GridBitSet struct {
+	BitSet
+	rows int
+	cols int
+	dirs []Vec2[int]
+}
+
+func NewGridBitSet(rows int, cols int, dirs []Vec2[int]) *GridBitSet {
+	return &GridBitSet{
+		BitSet: *NewBitSet(rows * cols * len(dirs)),
+		rows:   rows,
+		cols:   cols,
+		dirs:   dirs,
+	}
+}
+
+// bit index of a state; panics if pos is outside the grid or dir isn't one of the set's
+// directions
+func (g *GridBitSet) Index(pos Vec2[int], dir Vec2[int]) int {
+	if pos[0] < 0 || pos[0] >= g.rows || pos[1] < 0 || pos[1] >= g.cols {
+		panic(fmt.Sprintf("position %v outside %dx%d grid bitset", pos, g.rows, g.cols))
+	}
+	d := -1
+	for i, candidate := range g.dirs {
+		if candidate == dir {
+			d = i
+			break
+		}
+	}
+	if d == -1 {
+		panic("direction not in grid bitset")
+	}
+	return (pos[0]*g.cols+pos[1])*len(g.dirs) + d
+}
+
+// state for a bit index, the inverse of Index
+func (g *GridBitSet) State(i int) (Vec2[int], Vec2[int]) {
+	cell, d := i/len(g.dirs), i%len(g.dirs)
+	return Vec2[int]{cell / g.cols, cell % g.cols}, g.dirs[d]
+}
+
+func (g *GridBitSet) SetState(pos Vec2[int], dir Vec2[int]) {
+	g.Set(g.Index(pos, dir))
+}
+
+func (g *GridBitSet) TestState(pos Vec2[int], dir Vec2[int]) bool {
+	return g.Test(g.Index(pos, dir))
+}
+
+// whether pos has been set in any direction
+func (g *GridBitSet) TestPos(pos Vec2[int]) bool {
+	for _, dir := range g.dirs {
+		if g.TestState(pos, dir) {
+			return true
+		}
+	}
+	return false
+}
+
+// iterate over the distinct positions set in any direction, row by row
+func (g *GridBitSet) Positions() iter.Seq[Vec2[int]] {
+	return func(yield func(Vec2[int]) bool) {
+		last := -1
+		for i := range g.All() {
+			cell := i / len(g.dirs)
+			if cell == last {
+				continue
+			}
+			last = cell
+			if !yield(Vec2[int]{cell / g.cols, cell % g.cols}) {
+				return
+			}
+		}
+	}
+}
+
+// number of distinct positions set in any direction
+func (g *GridBitSet) CountPositions() int {
+	count := 0
+	for range g.Positions() {
+		count++
+	}
+	return count
+}
@@ -0,0 +1,46 @@
+package util
+
+import "testing"
+
+func TestGridBitSetIndexBounds(t *testing.T) {
+	g := NewGridBitSet(3, 4, Directions4)
+	for _, pos := range []Vec2[int]{{0, 4}, {3, 0}, {-1, 0}, {0, -1}} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("Index(%v) didn't panic", pos)
+				}
+			}()
+			g.Index(pos, Directions4[0])
+		}()
+	}
+
+	// every in-bounds state gets its own bit and round-trips through State
+	seen := make(Set[int])
+	for pos := range (Grid[bool]{make([]bool, 4), make([]bool, 4), make([]bool, 4)}).Positions() {
+		for _, dir := range Directions4 {
+			i := g.Index(pos, dir)
+			if seen.Has(i) {
+				t.Fatalf("duplicate index %d for %v %v", i, pos, dir)
+			}
+			seen.Add(i)
+			if p, d := g.State(i); p != pos || d != dir {
+				t.Errorf("State(%d) = %v %v, want %v %v", i, p, d, pos, dir)
+			}
+		}
+	}
+}
+
+func TestGridBitSetCountPositions(t *testing.T) {
+	g := NewGridBitSet(3, 4, Directions4)
+	g.SetState(Vec2[int]{0, 3}, Directions4[0])
+	g.SetState(Vec2[int]{0, 3}, Directions4[2])
+	g.SetState(Vec2[int]{1, 0}, Directions4[1])
+	g.SetState(Vec2[int]{2, 3}, Directions4[3])
+	if got := g.CountPositions(); got != 3 {
+		t.Errorf("CountPositions = %d, want 3", got)
+	}
+	if got := g.Count(); got != 4 {
+		t.Errorf("Count = %d, want 4", got)
+	}
+}
@@ -0,0 +1,190 @@
+package util
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+)
+
+// a + b, and whether the result overflowed T
+func AddChecked[T Integer](a T, b T) (T, bool) {
+	c := a + b
+	return c, (b > 0 && c < a) || (b < 0 && c > a)
+}
+
+// a - b, and whether the result overflowed T
+func SubChecked[T Integer](a T, b T) (T, bool) {
+	c := a - b
+	return c, (b > 0 && c > a) || (b < 0 && c < a)
+}
+
+// a * b, and whether the result overflowed T
+func MulChecked[T Integer](a T, b T) (T, bool) {
+	if a == 0 || b == 0 {
+		return 0, false
+	}
+	c := a * b
+	if b == -1 {
+		// only negating the minimum value overflows, and it wraps back to itself
+		return c, a != 0 && c == a
+	}
+	return c, c/b != a
+}
+
+// b to the nth power for n >= 0, and whether the result overflowed T
+func PowChecked[T Integer](b T, n int) (T, bool) {
+	res := T(1)
+	for n > 0 {
+		var overflow bool
+		if n&1 == 1 {
+			if res, overflow = MulChecked(res, b); overflow {
+				return res, true
+			}
+		}
+		n >>= 1
+		if n > 0 {
+			if b, overflow = MulChecked(b, b); overflow {
+				return res, true
+			}
+		}
+	}
+	return res, false
+}
+
+// a + b, panicking on overflow
+func MustAdd[T Integer](a T, b T) T {
+	res, overflow := AddChecked(a, b)
+	if overflow {
+		panic(fmt.Sprintf("integer overflow: %d + %d", a, b))
+	}
+	return res
+}
+
+// a - b, panicking on overflow
+func MustSub[T Integer](a T, b T) T {
+	res, overflow := SubChecked(a, b)
+	if overflow {
+		panic(fmt.Sprintf("integer overflow: %d - %d", a, b))
+	}
+	return res
+}
+
+// a * b, panicking on overflow
+func MustMul[T Integer](a T, b T) T {
+	res, overflow := MulChecked(a, b)
+	if overflow {
+		panic(fmt.Sprintf("integer overflow: %d * %d", a, b))
+	}
+	return res
+}
+
+// integer that's stored as an int64 while it fits and switches to *big.Int when it doesn't,
+// so arithmetic is always exact. the zero value is 0; values are immutable
+type SafeInt struct {
+	small int64
+	// non-nil iff the value doesn't fit in an int64
+	big *big.Int
+}
+
+func NewSafeInt(n int64) SafeInt {
+	return SafeInt{small: n}
+}
+
+func SafeIntFromBig(n *big.Int) SafeInt {
+	if n.IsInt64() {
+		return SafeInt{small: n.Int64()}
+	}
+	return SafeInt{big: new(big.Int).Set(n)}
+}
+
+// whether the value has been promoted to a big.Int
+func (a SafeInt) IsBig() bool {
+	return a.big != nil
+}
+
+// the value as an int64, and whether it fits
+func (a SafeInt) Int64() (int64, bool) {
+	return a.small, a.big == nil
+}
+
+// the value as a new big.Int
+func (a SafeInt) Big() *big.Int {
+	if a.big != nil {
+		return new(big.Int).Set(a.big)
+	}
+	return big.NewInt(a.small)
+}
+
+func (a SafeInt) Add(b SafeInt) SafeInt {
+	if a.big == nil && b.big == nil {
+		if c, overflow := AddChecked(a.small, b.small); !overflow {
+			return SafeInt{small: c}
+		}
+	}
+	return SafeIntFromBig(new(big.Int).Add(a.Big(), b.Big()))
+}
+
+func (a SafeInt) Sub(b SafeInt) SafeInt {
+	if a.big == nil && b.big == nil {
+		if c, overflow := SubChecked(a.small, b.small); !overflow {
+			return SafeInt{small: c}
+		}
+	}
+	return SafeIntFromBig(new(big.Int).Sub(a.Big(), b.Big()))
+}
+
+func (a SafeInt) Mul(b SafeInt) SafeInt {
+	if a.big == nil && b.big == nil {
+		if c, overflow := MulChecked(a.small, b.small); !overflow {
+			return SafeInt{small: c}
+		}
+	}
+	return SafeIntFromBig(new(big.Int).Mul(a.Big(), b.Big()))
+}
+
+// a to the nth power for n >= 0
+func (a SafeInt) Pow(n int) SafeInt {
+	if a.big == nil {
+		if c, overflow := PowChecked(a.small, n); !overflow {
+			return SafeInt{small: c}
+		}
+	}
+	return SafeIntFromBig(new(big.Int).Exp(a.Big(), big.NewInt(int64(n)), nil))
+}
+
+// quotient truncated towards zero, like the / operator; panics if b is 0
+func (a SafeInt) Quo(b SafeInt) SafeInt {
+	if a.big == nil && b.big == nil && !(a.small == math.MinInt64 && b.small == -1) {
+		return SafeInt{small: a.small / b.small}
+	}
+	return SafeIntFromBig(new(big.Int).Quo(a.Big(), b.Big()))
+}
+
+// remainder with the sign of a, like the % operator; panics if b is 0
+func (a SafeInt) Rem(b SafeInt) SafeInt {
+	if a.big == nil && b.big == nil && b.small != -1 {
+		return SafeInt{small: a.small % b.small}
+	}
+	return SafeIntFromBig(new(big.Int).Rem(a.Big(), b.Big()))
+}
+
+func (a SafeInt) Cmp(b SafeInt) int {
+	if a.big == nil && b.big == nil {
+		switch {
+		case a.small < b.small:
+			return -1
+		case a.small > b.small:
+			return 1
+		default:
+			return 0
+		}
+	}
+	return a.Big().Cmp(b.Big())
+}
+
+func (a SafeInt) String() string {
+	if a.big != nil {
+		return a.big.String()
+	}
+	return fmt.Sprint(a.small)
+}
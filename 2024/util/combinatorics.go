@@ -0,0 +1,149 @@
+package util
+
+import "iter"
+
+// the iterators below reuse one buffer for every value they yield, so callers that keep a
+// yielded slice past the current iteration must copy it (e.g. with slices.Clone)
+
+// k-element combinations of items in lexicographic order of their indices
+func Combinations[T any](items []T, k int) iter.Seq[[]T] {
+	return func(yield func([]T) bool) {
+		n := len(items)
+		if k < 0 || k > n {
+			return
+		}
+
+		indices := make([]int, k)
+		for i := range indices {
+			indices[i] = i
+		}
+		buf := make([]T, k)
+		for {
+			for i, idx := range indices {
+				buf[i] = items[idx]
+			}
+			if !yield(buf) {
+				return
+			}
+
+			// advance the rightmost index that still has room to move
+			i := k - 1
+			for i >= 0 && indices[i] == n-k+i {
+				i--
+			}
+			if i < 0 {
+				return
+			}
+			indices[i]++
+			for j := i + 1; j < k; j++ {
+				indices[j] = indices[j-1] + 1
+			}
+		}
+	}
+}
+
+// every ordering of items, in lexicographic order of their indices
+func Permutations[T any](items []T) iter.Seq[[]T] {
+	return func(yield func([]T) bool) {
+		n := len(items)
+		indices := make([]int, n)
+		for i := range indices {
+			indices[i] = i
+		}
+		buf := make([]T, n)
+		for {
+			for i, idx := range indices {
+				buf[i] = items[idx]
+			}
+			if !yield(buf) {
+				return
+			}
+
+			// standard next-permutation: find the last ascent, swap it with the smallest larger
+			// element after it, then reverse the tail
+			i := n - 2
+			for i >= 0 && indices[i] >= indices[i+1] {
+				i--
+			}
+			if i < 0 {
+				return
+			}
+			j := n - 1
+			for indices[j] <= indices[i] {
+				j--
+			}
+			indices[i], indices[j] = indices[j], indices[i]
+			for l, r := i+1, n-1; l < r; l, r = l+1, r-1 {
+				indices[l], indices[r] = indices[r], indices[l]
+			}
+		}
+	}
+}
+
+// every length-n sequence over alphabet (the cartesian power alphabet^n), varying the last
+// element fastest, e.g. the possible operator sequences between n+1 values
+func Product[T any](alphabet []T, n int) iter.Seq[[]T] {
+	return func(yield func([]T) bool) {
+		if n > 0 && len(alphabet) == 0 {
+			return
+		}
+
+		// odometer of indices into alphabet
+		indices := make([]int, n)
+		buf := make([]T, n)
+		for i := range buf {
+			buf[i] = alphabet[0]
+		}
+		for {
+			if !yield(buf) {
+				return
+			}
+
+			i := n - 1
+			for i >= 0 && indices[i] == len(alphabet)-1 {
+				indices[i] = 0
+				buf[i] = alphabet[0]
+				i--
+			}
+			if i < 0 {
+				return
+			}
+			indices[i]++
+			buf[i] = alphabet[indices[i]]
+		}
+	}
+}
+
+// every pair (items[i], items[j]) with i < j
+func Pairs[T any](items []T) iter.Seq2[T, T] {
+	return func(yield func(T, T) bool) {
+		for i := range items {
+			for j := i + 1; j < len(items); j++ {
+				if !yield(items[i], items[j]) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// items with each element removed in turn, yielded with the index of the removed element
+func EachRemoved[T any](items []T) iter.Seq2[int, []T] {
+	return func(yield func(int, []T) bool) {
+		if len(items) == 0 {
+			return
+		}
+
+		// start with items[1:], then shift the hole left to right by restoring one element per step
+		buf := make([]T, len(items)-1)
+		copy(buf, items[1:])
+		for i := range items {
+			if i > 0 {
+				buf[i-1] = items[i-1]
+			}
+			if !yield(i, buf) {
+				return
+			}
+		}
+	}
+}
@@ -1,9 +1,64 @@
 package util
 
-func Unpack(c complex64) (int, int) {
-	return int(real(c)), int(imag(c))
+// exact Gaussian integer {re, im} = re + im*i, for positions and directions where turning is
+// multiplication by i. comparable, so it can be used as a map key
+//
+// like Vec2 and Grid, the real part is the row and the imaginary part is the column,
+// so RotateLeft (multiplying by i) turns counter-clockwise as seen on screen
+type GaussInt [2]int
+
+var GaussI = GaussInt{0, 1}
+
+func NewGaussInt(re int, im int) GaussInt {
+	return GaussInt{re, im}
+}
+
+func GaussIntFromVec2(v Vec2[int]) GaussInt {
+	return GaussInt(v)
+}
+
+func (z GaussInt) Vec2() Vec2[int] {
+	return Vec2[int](z)
+}
+
+func (z GaussInt) Re() int {
+	return z[0]
+}
+
+func (z GaussInt) Im() int {
+	return z[1]
+}
+
+func (z GaussInt) Parts() (int, int) {
+	return z[0], z[1]
+}
+
+func (z GaussInt) Add(w GaussInt) GaussInt {
+	return GaussInt{z[0] + w[0], z[1] + w[1]}
+}
+
+func (z GaussInt) Sub(w GaussInt) GaussInt {
+	return GaussInt{z[0] - w[0], z[1] - w[1]}
+}
+
+func (z GaussInt) Mul(w GaussInt) GaussInt {
+	return GaussInt{z[0]*w[0] - z[1]*w[1], z[0]*w[1] + z[1]*w[0]}
+}
+
+func (z GaussInt) Scale(c int) GaussInt {
+	return GaussInt{c * z[0], c * z[1]}
+}
+
+func (z GaussInt) Neg() GaussInt {
+	return GaussInt{-z[0], -z[1]}
+}
+
+// z * i
+func (z GaussInt) RotateLeft() GaussInt {
+	return GaussInt{-z[1], z[0]}
 }
 
-func Pack(a int, b int) complex64 {
-	return complex(float32(a), float32(b))
+// z * -i
+func (z GaussInt) RotateRight() GaussInt {
+	return GaussInt{z[1], -z[0]}
 }
@@ -0,0 +1,106 @@
+package util
+
+import (
+	"cmp"
+	"reflect"
+	"slices"
+)
+
+// multiset of values with their counts. values whose count drops to zero are removed, so
+// len(c) is the number of distinct values
+type Counter[T comparable] map[T]int
+
+type CounterEntry[T comparable] struct {
+	Value T
+	Count int
+}
+
+func NewCounter[T comparable]() Counter[T] {
+	return make(Counter[T])
+}
+
+// adjust the count of v by n, which may be negative
+func (c Counter[T]) Add(v T, n int) {
+	c[v] += n
+	if c[v] == 0 {
+		delete(c, v)
+	}
+}
+
+// count each value once
+func (c Counter[T]) AddAll(vals []T) {
+	for _, v := range vals {
+		c.Add(v, 1)
+	}
+}
+
+func (c Counter[T]) Get(v T) int {
+	return c[v]
+}
+
+// sum of all counts
+func (c Counter[T]) Total() int {
+	total := 0
+	for _, n := range c {
+		total += n
+	}
+	return total
+}
+
+// the k values with the highest counts, highest first (ties in the order Set.String uses).
+// k <= 0 returns every value
+func (c Counter[T]) MostCommon(k int) []CounterEntry[T] {
+	entries := make([]CounterEntry[T], 0, len(c))
+	for v, n := range c {
+		entries = append(entries, CounterEntry[T]{v, n})
+	}
+	slices.SortFunc(entries, func(a, b CounterEntry[T]) int {
+		return cmp.Or(cmp.Compare(b.Count, a.Count), compareValues(reflect.ValueOf(a.Value), reflect.ValueOf(b.Value)))
+	})
+
+	if k > 0 && k < len(entries) {
+		entries = entries[:k]
+	}
+	return entries
+}
+
+func (c Counter[T]) Clone() Counter[T] {
+	res := make(Counter[T], len(c))
+	for v, n := range c {
+		res[v] = n
+	}
+	return res
+}
+
+// add every count in other to c
+func (c Counter[T]) AddCounter(other Counter[T]) {
+	for v, n := range other {
+		c.Add(v, n)
+	}
+}
+
+// subtract every count in other from c
+func (c Counter[T]) SubtractCounter(other Counter[T]) {
+	for v, n := range other {
+		c.Add(v, -n)
+	}
+}
+
+// replace every element simultaneously with the elements f emits for it, returning the new
+// counter. each emitted value inherits the count of the element that emitted it
+func (c Counter[T]) Transform(f func(v T, emit func(T))) Counter[T] {
+	next := make(Counter[T], len(c))
+	c.TransformInto(next, f)
+	return next
+}
+
+// like Transform, but writes into next (clearing it first) so that repeated steps can swap
+// between two counters instead of allocating a new one each time
+func (c Counter[T]) TransformInto(next Counter[T], f func(v T, emit func(T))) {
+	clear(next)
+	for v, n := range c {
+		f(v, func(out T) {
+			next.Add(out, n)
+		})
+	}
+}
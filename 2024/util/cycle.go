@@ -0,0 +1,99 @@
+package util
+
+// find the cycle in the sequence x0, f(x0), f(f(x0)), ... using Brent's algorithm, which needs
+// only constant memory. returns mu, the index of the first state on the cycle, and lambda,
+// the length of the cycle. never returns if the sequence doesn't cycle
+func Brent[S comparable](x0 S, f func(S) S) (int, int) {
+	// find the cycle length by racing a hare ahead of a tortoise that teleports to the
+	// hare at every power of two
+	power, lambda := 1, 1
+	tortoise, hare := x0, f(x0)
+	for tortoise != hare {
+		if power == lambda {
+			tortoise = hare
+			power *= 2
+			lambda = 0
+		}
+		hare = f(hare)
+		lambda++
+	}
+
+	// with the hare lambda steps ahead, they first meet at the start of the cycle
+	tortoise, hare = x0, x0
+	for range lambda {
+		hare = f(hare)
+	}
+	mu := 0
+	for tortoise != hare {
+		tortoise = f(tortoise)
+		hare = f(hare)
+		mu++
+	}
+	return mu, lambda
+}
+
+// a sequence of states that eventually repeats
+type Cycle[S any] struct {
+	// index of the first state on the cycle
+	Start  int
+	Length int
+	// every state from x0 up to the end of the first pass through the cycle
+	History []S
+}
+
+// state after n steps from x0
+func (c Cycle[S]) At(n int) S {
+	if n >= c.Start {
+		n = c.Start + (n-c.Start)%c.Length
+	}
+	return c.History[n]
+}
+
+// find the cycle in the sequence x0, f(x0), f(f(x0)), ... by remembering every state,
+// which costs memory but steps f only once per state
+func FindCycle[S comparable](x0 S, f func(S) S) Cycle[S] {
+	return FindCycleKey(x0, f, func(s S) S { return s })
+}
+
+// like FindCycle, for states that aren't comparable (e.g. grids): key should map equal states
+// to equal keys, such as a string rendering of the grid. every state is kept in History, so
+// f must return a fresh state rather than mutate its argument in place
+func FindCycleKey[S any, K comparable](x0 S, f func(S) S, key func(S) K) Cycle[S] {
+	c, _ := findCycle(x0, f, key, -1)
+	return c
+}
+
+// state after n steps from x0, skipping whole cycles once one is detected, so e.g.
+// n = 1e9 only costs as much as simulating until the first repeat
+func FastForward[S comparable](x0 S, f func(S) S, n int) S {
+	return FastForwardKey(x0, f, func(s S) S { return s }, n)
+}
+
+// like FastForward, for states that aren't comparable. like FindCycleKey it keeps every state
+// it sees, so f must return a fresh state rather than mutate its argument in place
+func FastForwardKey[S any, K comparable](x0 S, f func(S) S, key func(S) K, n int) S {
+	c, found := findCycle(x0, f, key, n)
+	if !found {
+		// reached step n before anything repeated
+		return c.History[n]
+	}
+	return c.At(n)
+}
+
+// step until a state repeats or, if limit >= 0, until limit steps have been taken
+func findCycle[S any, K comparable](x0 S, f func(S) S, key func(S) K, limit int) (Cycle[S], bool) {
+	seen := make(map[K]int)
+	history := []S{x0}
+	s := x0
+	for i := 0; ; i++ {
+		if first, ok := seen[key(s)]; ok {
+			return Cycle[S]{Start: first, Length: i - first, History: history[:i]}, true
+		}
+		if i == limit {
+			return Cycle[S]{History: history}, false
+		}
+		seen[key(s)] = i
+		s = f(s)
+		history = append(history, s)
+	}
+}
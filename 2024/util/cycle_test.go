@@ -0,0 +1,79 @@
+package util
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+// 0 -> 1 -> 2 -> 3 -> 4 -> 5 -> 2, so the cycle starts at index 2 and has length 4
+var rho = map[int]int{0: 1, 1: 2, 2: 3, 3: 4, 4: 5, 5: 2}
+
+func rhoStep(x int) int {
+	return rho[x]
+}
+
+func TestBrent(t *testing.T) {
+	if mu, lambda := Brent(0, rhoStep); mu != 2 || lambda != 4 {
+		t.Errorf("Brent = %d, %d, want 2, 4", mu, lambda)
+	}
+	// starting on the cycle
+	if mu, lambda := Brent(3, rhoStep); mu != 0 || lambda != 4 {
+		t.Errorf("Brent from 3 = %d, %d, want 0, 4", mu, lambda)
+	}
+	// a fixed point
+	if mu, lambda := Brent(7, func(x int) int { return min(x+1, 9) }); mu != 2 || lambda != 1 {
+		t.Errorf("Brent to fixed point = %d, %d, want 2, 1", mu, lambda)
+	}
+}
+
+func TestFindCycle(t *testing.T) {
+	c := FindCycle(0, rhoStep)
+	if c.Start != 2 || c.Length != 4 || !slices.Equal(c.History, []int{0, 1, 2, 3, 4, 5}) {
+		t.Errorf("FindCycle = %+v", c)
+	}
+}
+
+func TestFastForward(t *testing.T) {
+	tests := []struct {
+		n, want int
+	}{
+		{0, 0},
+		{1, 1},
+		// the start of the cycle, and one full lap later
+		{2, 2},
+		{6, 2},
+		{7, 3},
+		{1_000_000_001, 2 + (1_000_000_001-2)%4},
+	}
+	for _, tt := range tests {
+		if got := FastForward(0, rhoStep, tt.n); got != tt.want {
+			t.Errorf("FastForward(%d) = %d, want %d", tt.n, got, tt.want)
+		}
+	}
+
+	// step n is reached before anything repeats, including for a sequence that never does
+	if got := FastForward(0, rhoStep, 4); got != 4 {
+		t.Errorf("FastForward(4) = %d, want 4", got)
+	}
+	if got := FastForward(0, func(x int) int { return x + 1 }, 1000); got != 1000 {
+		t.Errorf("FastForward without cycle = %d, want 1000", got)
+	}
+}
+
+func TestFastForwardKey(t *testing.T) {
+	// rotate a slice left, returning a fresh slice each step
+	rotate := func(s []string) []string {
+		return append(slices.Clone(s[1:]), s[0])
+	}
+	key := func(s []string) string { return strings.Join(s, "") }
+	got := FastForwardKey([]string{"a", "b", "c"}, rotate, key, 1_000_000_001)
+	if want := []string{"c", "a", "b"}; !slices.Equal(got, want) {
+		t.Errorf("FastForwardKey = %v, want %v", got, want)
+	}
+
+	c := FindCycleKey([]string{"a", "b", "c"}, rotate, key)
+	if c.Start != 0 || c.Length != 3 {
+		t.Errorf("FindCycleKey = %d, %d, want 0, 3", c.Start, c.Length)
+	}
+}
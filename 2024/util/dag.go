@@ -0,0 +1,67 @@
+package util
+
+// paths from every node of a DAG to its sinks
+type DAGPaths[S comparable] struct {
+	// number of distinct paths from each node to any sink
+	Paths map[S]int
+	// every sink, in the order they're indexed in Reachable
+	Sinks []S
+	// sinks reachable from each node, as indices into Sinks. nil unless requested
+	Reachable map[S]*BitSet
+}
+
+// count paths from each node to the sinks, iterating in reverse topological order rather than
+// recursing. paths end at the first sink they reach. nodes reachable from the given ones via
+// successors are included too
+//
+// returns a *CycleError if the graph isn't acyclic
+func CountDAGPaths[S comparable](nodes []S, successors NeighborFunc[S], isSink func(S) bool, trackSinks bool) (DAGPaths[S], error) {
+	g := NewDigraph[S]()
+	for _, s := range nodes {
+		g.AddNode(s)
+	}
+	// successors can discover nodes beyond the given ones, so walk outwards until the graph is complete
+	for i := 0; i < len(g.nodes); i++ {
+		s := g.nodes[i]
+		if isSink(s) {
+			continue
+		}
+		for next := range successors(s) {
+			g.AddEdge(s, next)
+		}
+	}
+
+	order, err := g.TopoSort()
+	if err != nil {
+		return DAGPaths[S]{}, err
+	}
+
+	res := DAGPaths[S]{Paths: make(map[S]int)}
+	if trackSinks {
+		res.Reachable = make(map[S]*BitSet)
+	}
+	for i := len(order) - 1; i >= 0; i-- {
+		s := order[i]
+		if isSink(s) {
+			res.Paths[s] = 1
+			if trackSinks {
+				res.Reachable[s] = NewBitSet(0)
+				res.Reachable[s].Set(len(res.Sinks))
+			}
+			res.Sinks = append(res.Sinks, s)
+			continue
+		}
+
+		if trackSinks {
+			res.Reachable[s] = NewBitSet(len(res.Sinks))
+		}
+		for _, next := range g.succs[s] {
+			res.Paths[s] += res.Paths[next]
+			if trackSinks {
+				res.Reachable[s].Or(res.Reachable[next])
+			}
+		}
+	}
+
+	return res, nil
+}
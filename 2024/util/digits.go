@@ -0,0 +1,118 @@
+package util
+
+import (
+	"fmt"
+	"math/bits"
+	"slices"
+)
+
+// powers of ten that fit in an int64
+var pow10 = func() [19]int64 {
+	var table [19]int64
+	table[0] = 1
+	for i := 1; i < len(table); i++ {
+		table[i] = table[i-1] * 10
+	}
+	return table
+}()
+
+// 10^n for 0 <= n <= 18, panicking if it doesn't fit in an int64
+func Pow10(n int) int64 {
+	if n < 0 || n >= len(pow10) {
+		panic(fmt.Sprintf("integer overflow: 10^%d", n))
+	}
+	return pow10[n]
+}
+
+// |n| as a uint64, which unlike absInt can't overflow on the most negative value
+func magnitude[T Integer](n T) uint64 {
+	u := uint64(n)
+	if n < 0 {
+		u = -u
+	}
+	return u
+}
+
+// number of decimal digits in |n|, with DigitCount(0) = 1
+func DigitCount[T Integer](n T) int {
+	u := magnitude(n)
+	// log10(2) ~= 1233/4096, so this is the digit count or one less
+	guess := bits.Len64(u) * 1233 >> 12
+	if guess < len(pow10) && u >= uint64(pow10[guess]) {
+		guess++
+	}
+	return max(guess, 1)
+}
+
+// digits of a followed by digits of b, e.g. Concat(12, 345) = 12345, for a, b >= 0.
+// panics on overflow
+func Concat[T Integer](a T, b T) T {
+	return MustAdd(MustMul(a, T(Pow10(DigitCount(b)))), b)
+}
+
+// strip the digits of b from the end of ab, the inverse of Concat. returns false if ab
+// doesn't end with b, e.g. Unconcat(12345, 45) = 123, true
+func Unconcat[T Integer](ab T, b T) (T, bool) {
+	n := DigitCount(b)
+	if n > DigitCount(ab) {
+		return 0, false
+	}
+	div := T(Pow10(n))
+	if ab%div != b {
+		return 0, false
+	}
+	return ab / div, true
+}
+
+// split n >= 0 into the left and right halves of its digits, e.g. SplitHalf(1234) = 12, 34, true.
+// returns false if n has an odd number of digits
+func SplitHalf[T Integer](n T) (T, T, bool) {
+	digits := DigitCount(n)
+	if digits%2 != 0 {
+		return 0, 0, false
+	}
+	div := T(Pow10(digits / 2))
+	return n / div, n % div, true
+}
+
+// digits of |n| in the given base, most significant first, with Digits(0, base) = [0]
+func Digits[T Integer](n T, base int) []int {
+	u := magnitude(n)
+	if u == 0 {
+		return []int{0}
+	}
+
+	var digits []int
+	for ; u > 0; u /= uint64(base) {
+		digits = append(digits, int(u%uint64(base)))
+	}
+	slices.Reverse(digits)
+	return digits
+}
+
+// number with the given digits in the given base, most significant first. panics on overflow
+func FromDigits[T Integer](digits []int, base int) T {
+	var n T
+	for _, d := range digits {
+		n = MustAdd(MustMul(n, T(base)), T(d))
+	}
+	return n
+}
+
+// sum of the digits of |n| in the given base
+func DigitSum[T Integer](n T, base int) int {
+	sum := 0
+	for u := magnitude(n); u > 0; u /= uint64(base) {
+		sum += int(u % uint64(base))
+	}
+	return sum
+}
+
+// digits of |n| in reverse order, e.g. ReverseDigits(1230) = 321. panics on overflow
+func ReverseDigits[T Integer](n T) T {
+	var res T
+	for u := magnitude(n); u > 0; u /= 10 {
+		res = MustAdd(MustMul(res, 10), T(u%10))
+	}
+	return res
+}
@@ -0,0 +1,176 @@
+package util
+
+import (
+	"math"
+	"slices"
+	"testing"
+)
+
+// whether f panics
+func panics(f func()) (panicked bool) {
+	defer func() {
+		panicked = recover() != nil
+	}()
+	f()
+	return false
+}
+
+func TestDigitCount(t *testing.T) {
+	tests := []struct {
+		n    int64
+		want int
+	}{
+		{0, 1},
+		{9, 1},
+		{10, 2},
+		{-10, 2},
+		{99, 2},
+		{100, 3},
+		{1e18 - 1, 18},
+		{1e18, 19},
+		{math.MaxInt64, 19},
+		{math.MinInt64, 19},
+	}
+	for _, tt := range tests {
+		if got := DigitCount(tt.n); got != tt.want {
+			t.Errorf("DigitCount(%d) = %d, want %d", tt.n, got, tt.want)
+		}
+	}
+	// every power of ten boundary
+	for n := 1; n < 19; n++ {
+		if got := DigitCount(Pow10(n) - 1); got != n {
+			t.Errorf("DigitCount(10^%d - 1) = %d", n, got)
+		}
+		if got := DigitCount(Pow10(n)); got != n+1 {
+			t.Errorf("DigitCount(10^%d) = %d", n, got)
+		}
+	}
+}
+
+func TestConcat(t *testing.T) {
+	tests := []struct {
+		a, b, want int64
+	}{
+		{12, 345, 12345},
+		// 0 still has one digit, so it shifts a left
+		{7, 0, 70},
+		{0, 5, 5},
+		{15, 6, 156},
+		{922337203685477580, 7, math.MaxInt64},
+	}
+	for _, tt := range tests {
+		if got := Concat(tt.a, tt.b); got != tt.want {
+			t.Errorf("Concat(%d, %d) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+
+	if !panics(func() { Concat[int64](922337203685477580, 8) }) {
+		t.Error("Concat past MaxInt64 didn't panic")
+	}
+	if !panics(func() { Concat[int64](1e10, 1e9) }) {
+		t.Error("Concat overflowing the multiply didn't panic")
+	}
+}
+
+func TestUnconcat(t *testing.T) {
+	tests := []struct {
+		ab, b, want int64
+		ok          bool
+	}{
+		{12345, 45, 123, true},
+		{12345, 12345, 0, true},
+		{70, 0, 7, true},
+		{12345, 46, 0, false},
+		{45, 345, 0, false},
+		{12305, 5, 1230, true},
+		{12305, 305, 12, true},
+		{105, 5, 10, true},
+	}
+	for _, tt := range tests {
+		got, ok := Unconcat(tt.ab, tt.b)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("Unconcat(%d, %d) = %d, %v, want %d, %v", tt.ab, tt.b, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestSplitHalf(t *testing.T) {
+	tests := []struct {
+		n, left, right int64
+		ok             bool
+	}{
+		{1234, 12, 34, true},
+		{1000, 10, 0, true},
+		{10, 1, 0, true},
+		{123, 0, 0, false},
+		{7, 0, 0, false},
+		{0, 0, 0, false},
+	}
+	for _, tt := range tests {
+		left, right, ok := SplitHalf(tt.n)
+		if left != tt.left || right != tt.right || ok != tt.ok {
+			t.Errorf("SplitHalf(%d) = %d, %d, %v, want %d, %d, %v", tt.n, left, right, ok, tt.left, tt.right, tt.ok)
+		}
+	}
+}
+
+func TestDigits(t *testing.T) {
+	tests := []struct {
+		n    int64
+		base int
+		want []int
+	}{
+		{0, 10, []int{0}},
+		{1230, 10, []int{1, 2, 3, 0}},
+		{-42, 10, []int{4, 2}},
+		{10, 2, []int{1, 0, 1, 0}},
+		{255, 16, []int{15, 15}},
+		{math.MinInt64, 10, []int{9, 2, 2, 3, 3, 7, 2, 0, 3, 6, 8, 5, 4, 7, 7, 5, 8, 0, 8}},
+	}
+	for _, tt := range tests {
+		got := Digits(tt.n, tt.base)
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("Digits(%d, %d) = %v, want %v", tt.n, tt.base, got, tt.want)
+		}
+		if tt.n >= 0 {
+			if back := FromDigits[int64](got, tt.base); back != tt.n {
+				t.Errorf("FromDigits(%v, %d) = %d, want %d", got, tt.base, back, tt.n)
+			}
+		}
+	}
+
+	if got := DigitSum(int64(math.MinInt64), 10); got != 89 {
+		t.Errorf("DigitSum(MinInt64) = %d, want 89", got)
+	}
+	if got := ReverseDigits(int64(1230)); got != 321 {
+		t.Errorf("ReverseDigits(1230) = %d, want 321", got)
+	}
+}
+
+func TestFromDigitsOverflow(t *testing.T) {
+	maxDigits := Digits(int64(math.MaxInt64), 10)
+	if got := FromDigits[int64](maxDigits, 10); got != math.MaxInt64 {
+		t.Errorf("FromDigits(MaxInt64 digits) = %d", got)
+	}
+
+	over := slices.Clone(maxDigits)
+	over[len(over)-1]++
+	if !panics(func() { FromDigits[int64](over, 10) }) {
+		t.Error("FromDigits past MaxInt64 didn't panic")
+	}
+	if !panics(func() { FromDigits[int64](append(maxDigits, 0), 10) }) {
+		t.Error("FromDigits with too many digits didn't panic")
+	}
+	if !panics(func() { ReverseDigits(int64(1999999999999999999)) }) {
+		t.Error("ReverseDigits past MaxInt64 didn't panic")
+	}
+}
+
+func TestPow10(t *testing.T) {
+	if got := Pow10(18); got != 1e18 {
+		t.Errorf("Pow10(18) = %d", got)
+	}
+	if !panics(func() { Pow10(19) }) || !panics(func() { Pow10(-1) }) {
+		t.Error("Pow10 out of range didn't panic")
+	}
+}
@@ -0,0 +1,179 @@
+package util
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// directed graph where an edge a -> b means "a must come before b"
+//
+// nodes and edges are kept in insertion order so that sorts are deterministic
+type Digraph[T comparable] struct {
+	nodes []T
+	succs map[T][]T
+	edges Set[[2]T]
+}
+
+func NewDigraph[T comparable]() *Digraph[T] {
+	return &Digraph[T]{
+		succs: make(map[T][]T),
+		edges: make(Set[[2]T]),
+	}
+}
+
+func (g *Digraph[T]) AddNode(v T) {
+	if _, ok := g.succs[v]; !ok {
+		g.nodes = append(g.nodes, v)
+		g.succs[v] = nil
+	}
+}
+
+func (g *Digraph[T]) AddEdge(from T, to T) {
+	g.AddNode(from)
+	g.AddNode(to)
+	if !g.edges.Has([2]T{from, to}) {
+		g.edges.Add([2]T{from, to})
+		g.succs[from] = append(g.succs[from], to)
+	}
+}
+
+func (g *Digraph[T]) HasNode(v T) bool {
+	_, ok := g.succs[v]
+	return ok
+}
+
+func (g *Digraph[T]) HasEdge(from T, to T) bool {
+	return g.edges.Has([2]T{from, to})
+}
+
+func (g *Digraph[T]) Nodes() []T {
+	return slices.Clone(g.nodes)
+}
+
+func (g *Digraph[T]) Successors(v T) []T {
+	return slices.Clone(g.succs[v])
+}
+
+// subgraph induced by the given nodes, i.e. only edges with both ends in the subset. nodes
+// that aren't in the graph are added as isolated nodes
+func (g *Digraph[T]) Restrict(subset []T) *Digraph[T] {
+	keep := make(Set[T])
+	keep.AddAll(subset)
+
+	sub := NewDigraph[T]()
+	for _, v := range subset {
+		sub.AddNode(v)
+	}
+	for _, v := range sub.nodes {
+		for _, w := range g.succs[v] {
+			if keep.Has(w) {
+				sub.AddEdge(v, w)
+			}
+		}
+	}
+	return sub
+}
+
+// reported by TopoSort when the graph has no valid ordering
+type CycleError[T comparable] struct {
+	// nodes around one cycle, each with an edge to the next and the last back to the first
+	Cycle []T
+}
+
+func (e *CycleError[T]) Error() string {
+	parts := make([]string, len(e.Cycle)+1)
+	for i, v := range e.Cycle {
+		parts[i] = fmt.Sprint(v)
+	}
+	parts[len(e.Cycle)] = fmt.Sprint(e.Cycle[0])
+	return "cycle: " + strings.Join(parts, " -> ")
+}
+
+// order the nodes so every edge points forwards, using Kahn's algorithm. if that's impossible,
+// returns a *CycleError listing one offending cycle
+func (g *Digraph[T]) TopoSort() ([]T, error) {
+	indegree := make(map[T]int)
+	for _, v := range g.nodes {
+		for _, w := range g.succs[v] {
+			indegree[w]++
+		}
+	}
+
+	var queue Queue[T]
+	for _, v := range g.nodes {
+		if indegree[v] == 0 {
+			queue.Push(v)
+		}
+	}
+
+	order := make([]T, 0, len(g.nodes))
+	for queue.Len() > 0 {
+		v := queue.Pop()
+		order = append(order, v)
+		for _, w := range g.succs[v] {
+			indegree[w]--
+			if indegree[w] == 0 {
+				queue.Push(w)
+			}
+		}
+	}
+
+	if len(order) < len(g.nodes) {
+		return nil, &CycleError[T]{g.findCycle(indegree)}
+	}
+	return order, nil
+}
+
+// find a cycle among the nodes Kahn's algorithm couldn't place, i.e. those left with
+// nonzero indegree. each of them has a predecessor that's also left, so walking
+// predecessors must eventually repeat a node
+func (g *Digraph[T]) findCycle(indegree map[T]int) []T {
+	pred := make(map[T]T)
+	var start T
+	for _, v := range g.nodes {
+		if indegree[v] == 0 {
+			continue
+		}
+		start = v
+		for _, w := range g.succs[v] {
+			if indegree[w] > 0 {
+				pred[w] = v
+			}
+		}
+	}
+
+	seen := make(map[T]int)
+	var walk []T
+	v := start
+	for {
+		if i, ok := seen[v]; ok {
+			cycle := walk[i:]
+			// walk went backwards along edges
+			slices.Reverse(cycle)
+			return cycle
+		}
+		seen[v] = len(walk)
+		walk = append(walk, v)
+		v = pred[v]
+	}
+}
+
+// edges violated by the given ordering, i.e. from -> to where to appears before from.
+// edges touching nodes that aren't in the ordering are ignored
+func (g *Digraph[T]) Violations(order []T) [][2]T {
+	position := make(map[T]int)
+	for i, v := range order {
+		position[v] = i
+	}
+
+	var violations [][2]T
+	for _, v := range order {
+		for _, w := range g.succs[v] {
+			if j, ok := position[w]; ok && j < position[v] {
+				violations = append(violations, [2]T{v, w})
+			}
+		}
+	}
+	return violations
+}
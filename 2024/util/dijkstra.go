@@ -0,0 +1,161 @@
+package util
+
+import (
+	"iter"
+	"slices"
+)
+
+// result of a shortest path search from one or more sources
+type ShortestPaths[S comparable] struct {
+	// distance from the nearest source to every settled state
+	Dist map[S]int
+	// every predecessor of each state along some shortest path, so following it backwards
+	// gives all shortest paths. the first predecessor of a state was settled before it, but
+	// zero-cost edges can make the graph as a whole cyclic. sources never have predecessors.
+	// nil unless the search was asked to track paths
+	Prev map[S][]S
+	// goal states reached at the minimum goal distance, empty if no goal was reachable
+	Goals []S
+}
+
+// find shortest paths from sources, discovering states lazily via successors. edge costs must
+// be non-negative
+//
+// if isGoal is non-nil, the search stops once every goal at the minimum distance is settled, so
+// ties between several goals are all reported. with trackPaths set, Prev records all shortest
+// paths rather than just one
+func Dijkstra[S comparable](sources []S, successors SuccessorFunc[S], isGoal func(S) bool, trackPaths bool) ShortestPaths[S] {
+	sp := ShortestPaths[S]{Dist: make(map[S]int)}
+	if trackPaths {
+		sp.Prev = make(map[S][]S)
+	}
+
+	// tentative distances for states that are still queued
+	tentative := make(map[S]int)
+	pq := NewMinPriorityQueue[S, int]()
+	isSource := make(Set[S])
+	for _, s := range sources {
+		isSource.Add(s)
+		tentative[s] = 0
+		pq.Push(s, 0)
+	}
+
+	for pq.Len() > 0 {
+		s, dist := pq.Pop()
+		if len(sp.Goals) > 0 && dist > sp.Dist[sp.Goals[0]] {
+			break
+		}
+		sp.Dist[s] = dist
+		delete(tentative, s)
+		if isGoal != nil && isGoal(s) {
+			sp.Goals = append(sp.Goals, s)
+		}
+
+		for next, cost := range successors(s) {
+			total := dist + cost
+			// paths start at a source, so sources never get predecessors
+			if isSource.Has(next) {
+				continue
+			}
+			// a settled state can't be improved, only matched through a zero-cost edge
+			if _, settled := sp.Dist[next]; settled {
+				if trackPaths && total == sp.Dist[next] && next != s {
+					sp.Prev[next] = append(sp.Prev[next], s)
+				}
+				continue
+			}
+
+			best, seen := tentative[next]
+			switch {
+			case !seen || total < best:
+				tentative[next] = total
+				pq.Push(next, total)
+				if trackPaths {
+					sp.Prev[next] = []S{s}
+				}
+			case total == best && trackPaths:
+				sp.Prev[next] = append(sp.Prev[next], s)
+			}
+		}
+	}
+
+	return sp
+}
+
+// one shortest path from a source to target (inclusive), or nil if target wasn't reached.
+// requires the search to have tracked paths
+func (sp ShortestPaths[S]) Path(target S) []S {
+	if _, ok := sp.Dist[target]; !ok {
+		return nil
+	}
+
+	// first predecessors were settled strictly earlier, so following them can't loop
+	path := []S{target}
+	for s := target; len(sp.Prev[s]) > 0; {
+		s = sp.Prev[s][0]
+		path = append(path, s)
+	}
+	slices.Reverse(path)
+	return path
+}
+
+// every distinct simple shortest path from a source to target. the number of paths can grow
+// exponentially, so prefer OnShortestPaths when only the states involved are needed
+func (sp ShortestPaths[S]) AllPaths(target S) iter.Seq[[]S] {
+	return func(yield func([]S) bool) {
+		if _, ok := sp.Dist[target]; !ok {
+			return
+		}
+
+		// walk backwards from target, keeping the partial path (in reverse) in suffix. states
+		// already on it are skipped so zero-cost cycles can't loop the walk
+		onPath := make(Set[S])
+		var walk func(s S, suffix []S) bool
+		walk = func(s S, suffix []S) bool {
+			suffix = append(suffix, s)
+			if len(sp.Prev[s]) == 0 {
+				path := make([]S, len(suffix))
+				copy(path, suffix)
+				slices.Reverse(path)
+				return yield(path)
+			}
+			onPath.Add(s)
+			defer onPath.Remove(s)
+			for _, prev := range sp.Prev[s] {
+				if onPath.Has(prev) {
+					continue
+				}
+				if !walk(prev, suffix) {
+					return false
+				}
+			}
+			return true
+		}
+		walk(target, nil)
+	}
+}
+
+// set of states that lie on at least one shortest path to any of the targets. with zero-cost
+// cycles this also includes states only reachable by a zero-cost detour off such a path
+func (sp ShortestPaths[S]) OnShortestPaths(targets ...S) Set[S] {
+	states := make(Set[S])
+	var stack []S
+	for _, t := range targets {
+		if _, ok := sp.Dist[t]; ok && !states.Has(t) {
+			states.Add(t)
+			stack = append(stack, t)
+		}
+	}
+
+	for len(stack) > 0 {
+		s := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		for _, prev := range sp.Prev[s] {
+			if !states.Has(prev) {
+				states.Add(prev)
+				stack = append(stack, prev)
+			}
+		}
+	}
+	return states
+}
@@ -0,0 +1,92 @@
+package util
+
+import (
+	"iter"
+	"slices"
+	"testing"
+)
+
+// successors from a weighted adjacency list
+func edges(adj map[int][][2]int) SuccessorFunc[int] {
+	return func(s int) iter.Seq2[int, int] {
+		return func(yield func(int, int) bool) {
+			for _, e := range adj[s] {
+				if !yield(e[0], e[1]) {
+					return
+				}
+			}
+		}
+	}
+}
+
+func TestDijkstraAllShortestPaths(t *testing.T) {
+	// two paths of cost 2 from 0 to 3, and a longer one
+	adj := map[int][][2]int{
+		0: {{1, 1}, {2, 1}, {3, 5}},
+		1: {{3, 1}},
+		2: {{3, 1}},
+	}
+	sp := Dijkstra([]int{0}, edges(adj), func(s int) bool { return s == 3 }, true)
+	if sp.Dist[3] != 2 || !slices.Equal(sp.Goals, []int{3}) {
+		t.Fatalf("dist %d, goals %v", sp.Dist[3], sp.Goals)
+	}
+	paths := slices.Collect(sp.AllPaths(3))
+	if len(paths) != 2 {
+		t.Errorf("got paths %v, want 2", paths)
+	}
+	if got := sp.OnShortestPaths(3); !got.Equal(SetFromSeq(slices.Values([]int{0, 1, 2, 3}))) {
+		t.Errorf("OnShortestPaths = %v", got)
+	}
+}
+
+func TestDijkstraZeroCostCycle(t *testing.T) {
+	adj := map[int][][2]int{
+		0: {{1, 0}},
+		1: {{0, 0}, {2, 0}},
+		2: {{1, 0}},
+	}
+	sp := Dijkstra([]int{0}, edges(adj), nil, true)
+	if len(sp.Prev[0]) != 0 {
+		t.Errorf("source has predecessors %v", sp.Prev[0])
+	}
+	if got := sp.Path(2); !slices.Equal(got, []int{0, 1, 2}) {
+		t.Errorf("Path(2) = %v", got)
+	}
+	if got := slices.Collect(sp.AllPaths(2)); len(got) != 1 {
+		t.Errorf("AllPaths(2) = %v", got)
+	}
+}
+
+func TestDijkstraZeroCostBetweenSources(t *testing.T) {
+	adj := map[int][][2]int{
+		0: {{1, 0}, {2, 3}},
+		1: {{0, 0}, {2, 1}},
+	}
+	sp := Dijkstra([]int{0, 1}, edges(adj), nil, true)
+	if len(sp.Prev[0]) != 0 || len(sp.Prev[1]) != 0 {
+		t.Errorf("sources have predecessors %v", sp.Prev)
+	}
+	if got := sp.Path(2); !slices.Equal(got, []int{1, 2}) {
+		t.Errorf("Path(2) = %v", got)
+	}
+}
+
+func TestDijkstraZeroCostDiamond(t *testing.T) {
+	// 0 -> 2 directly and via 1, all free. both paths must be found whichever edge comes first
+	for _, out := range [][][2]int{{{1, 0}, {2, 0}}, {{2, 0}, {1, 0}}} {
+		adj := map[int][][2]int{
+			0: out,
+			1: {{2, 0}},
+		}
+		sp := Dijkstra([]int{0}, edges(adj), func(s int) bool { return s == 2 }, true)
+		if got := sp.OnShortestPaths(2); !got.Equal(SetFromSeq(slices.Values([]int{0, 1, 2}))) {
+			t.Errorf("edges %v: OnShortestPaths(2) = %v", out, got)
+		}
+		if got := slices.Collect(sp.AllPaths(2)); len(got) != 2 {
+			t.Errorf("edges %v: AllPaths(2) = %v", out, got)
+		}
+		if got := sp.Path(2); got[0] != 0 || got[len(got)-1] != 2 {
+			t.Errorf("edges %v: Path(2) = %v", out, got)
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package util
+
+import "iter"
+
+// yields (next state, edge cost) pairs reachable from a state in one move
+type SuccessorFunc[S any] func(s S) iter.Seq2[S, int]
+
+// yields states reachable from a state in one unit-cost move
+type NeighborFunc[S any] func(s S) iter.Seq[S]
+
+// exposes a grid as a graph over caller-defined search states, e.g. (position, facing)
+//
+// Successors, Neighbors and IsGoal can be passed directly to the shared search functions
+type GridGraph[T any, S comparable] struct {
+	Grid       Grid[T]
+	successors func(g Grid[T], s S, emit func(next S, cost int))
+	isGoal     func(s S) bool
+}
+
+// successors should call emit once for each state reachable from s along with the cost
+// of the move; isGoal may be nil if the search has no fixed target
+func NewGridGraph[T any, S comparable](
+	g Grid[T],
+	successors func(g Grid[T], s S, emit func(next S, cost int)),
+	isGoal func(s S) bool,
+) GridGraph[T, S] {
+	return GridGraph[T, S]{Grid: g, successors: successors, isGoal: isGoal}
+}
+
+// graph of orthogonal unit-cost steps between in-bounds cells accepted by passable
+func NewGridWalk[T any](g Grid[T], passable func(T) bool, goal Vec2[int]) GridGraph[T, Vec2[int]] {
+	return NewGridGraph(g, func(g Grid[T], pos Vec2[int], emit func(Vec2[int], int)) {
+		for _, dir := range Directions4 {
+			n := pos.Add(dir)
+			if g.InBounds(n) && passable(g.Get(n)) {
+				emit(n, 1)
+			}
+		}
+	}, func(pos Vec2[int]) bool {
+		return pos == goal
+	})
+}
+
+func (gg GridGraph[T, S]) Successors(s S) iter.Seq2[S, int] {
+	return func(yield func(S, int) bool) {
+		// the caller's successor func can't stop early, so just drop anything
+		// emitted after the consumer is done
+		done := false
+		gg.successors(gg.Grid, s, func(next S, cost int) {
+			if !done && !yield(next, cost) {
+				done = true
+			}
+		})
+	}
+}
+
+// successors of s ignoring edge costs, for unweighted searches
+func (gg GridGraph[T, S]) Neighbors(s S) iter.Seq[S] {
+	return func(yield func(S) bool) {
+		for next := range gg.Successors(s) {
+			if !yield(next) {
+				return
+			}
+		}
+	}
+}
+
+func (gg GridGraph[T, S]) IsGoal(s S) bool {
+	return gg.isGoal != nil && gg.isGoal(s)
+}
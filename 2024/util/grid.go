@@ -2,6 +2,7 @@ package util
 
 import (
 	"bufio"
+	"iter"
 	"strings"
 )
 
@@ -36,6 +37,19 @@ func (g Grid[T]) InBounds(pos Vec2[int]) bool {
 	return r >= 0 && r < len(g) && c >= 0 && c < len(g[0])
 }
 
+// every position in the grid, row by row
+func (g Grid[T]) Positions() iter.Seq[Vec2[int]] {
+	return func(yield func(Vec2[int]) bool) {
+		for r := range g {
+			for c := range g[r] {
+				if !yield(Vec2[int]{r, c}) {
+					return
+				}
+			}
+		}
+	}
+}
+
 func (g Grid[T]) Copy() Grid[T] {
 	newGrid := make(Grid[T], len(g))
 	for i, row := range g {
@@ -44,3 +58,9 @@ func (g Grid[T]) Copy() Grid[T] {
 	}
 	return newGrid
 }
+
+// orthogonal directions in (row, col) order: up, right, down, left
+var Directions4 = []Vec2[int]{{-1, 0}, {0, 1}, {1, 0}, {0, -1}}
+
+// orthogonal and diagonal directions, clockwise starting from up
+var Directions8 = []Vec2[int]{{-1, 0}, {-1, 1}, {0, 1}, {1, 1}, {1, 0}, {1, -1}, {0, -1}, {-1, -1}}
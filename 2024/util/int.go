@@ -1,6 +1,14 @@
 package util
 
-import "strconv"
+import (
+	"fmt"
+	"strconv"
+)
+
+// integer types usable as vector components
+type Integer interface {
+	~int | ~int64
+}
 
 func MustAtoi(s string) int {
 	i, err := strconv.Atoi(s)
@@ -26,36 +34,22 @@ func Abs(i int) int {
 	}
 }
 
-func RuneToInt(r rune) int {
-	return int(r - '0')
-}
-
-func DigitCountInt64(a int64) int {
-	var p int = 0
-	var base int64 = 1
-
-	if a == 0 {
-		return 1
-	}
-
-	for base <= a {
-		p++
-		base *= 10
+func absInt[T Integer](i T) T {
+	if i < 0 {
+		return -i
 	}
-
-	// p is the smallest int s.t. 10^p > a
-	return p
+	return i
 }
 
-func DigitCount(a int) int {
-	return DigitCountInt64(int64(a))
+func RuneToInt(r rune) int {
+	return int(r - '0')
 }
 
-// calculate b to the nth power
+// calculate b to the nth power, panicking if it overflows
 func ExpInt64(b int64, n int) int64 {
-	res := int64(1)
-	for range n {
-		res *= b
+	res, overflow := PowChecked(b, n)
+	if overflow {
+		panic(fmt.Sprintf("integer overflow: %d^%d", b, n))
 	}
 	return res
 }
@@ -0,0 +1,305 @@
+package util
+
+import (
+	"fmt"
+	"iter"
+)
+
+// half-open range [Lo, Hi); empty if Hi <= Lo
+type Interval[T Integer] struct {
+	Lo, Hi T
+}
+
+func NewInterval[T Integer](lo, hi T) Interval[T] {
+	return Interval[T]{lo, hi}
+}
+
+// interval of n values starting at lo
+func IntervalOfLen[T Integer](lo, n T) Interval[T] {
+	return Interval[T]{lo, lo + n}
+}
+
+func (iv Interval[T]) Len() T {
+	return max(iv.Hi-iv.Lo, 0)
+}
+
+func (iv Interval[T]) Empty() bool {
+	return iv.Hi <= iv.Lo
+}
+
+func (iv Interval[T]) Contains(x T) bool {
+	return iv.Lo <= x && x < iv.Hi
+}
+
+func (iv Interval[T]) Overlaps(o Interval[T]) bool {
+	return !iv.Intersect(o).Empty()
+}
+
+// may be empty
+func (iv Interval[T]) Intersect(o Interval[T]) Interval[T] {
+	return Interval[T]{max(iv.Lo, o.Lo), min(iv.Hi, o.Hi)}
+}
+
+func (iv Interval[T]) Shift(d T) Interval[T] {
+	return Interval[T]{iv.Lo + d, iv.Hi + d}
+}
+
+func (iv Interval[T]) String() string {
+	return fmt.Sprintf("[%d,%d)", iv.Lo, iv.Hi)
+}
+
+// set of integers stored as sorted, disjoint, non-adjacent intervals in a balanced tree, so
+// edits, lookups and first-fit searches take O(log n) expected time in the number of intervals.
+// the zero value is an empty set
+type RangeSet[T Integer] struct {
+	root *spanNode[T, struct{}]
+}
+
+func NewRangeSet[T Integer](ivs ...Interval[T]) *RangeSet[T] {
+	s := &RangeSet[T]{}
+	for _, iv := range ivs {
+		s.Add(iv)
+	}
+	return s
+}
+
+// sorted union of the intervals, with overlapping and touching ones merged
+func MergeIntervals[T Integer](ivs []Interval[T]) []Interval[T] {
+	return NewRangeSet(ivs...).Intervals()
+}
+
+// total number of values in the set
+func (s *RangeSet[T]) Len() T {
+	return spanTotal(s.root)
+}
+
+// number of maximal intervals making up the set
+func (s *RangeSet[T]) NumIntervals() int {
+	return spanCount(s.root)
+}
+
+// iterate over the maximal intervals in increasing order
+func (s *RangeSet[T]) All() iter.Seq[Interval[T]] {
+	return func(yield func(Interval[T]) bool) {
+		for n := range allSpans(s.root) {
+			if !yield(n.span) {
+				return
+			}
+		}
+	}
+}
+
+func (s *RangeSet[T]) Intervals() []Interval[T] {
+	res := make([]Interval[T], 0, s.NumIntervals())
+	for iv := range s.All() {
+		res = append(res, iv)
+	}
+	return res
+}
+
+func (s *RangeSet[T]) Clone() *RangeSet[T] {
+	return &RangeSet[T]{cloneSpans(s.root)}
+}
+
+func (s *RangeSet[T]) Contains(x T) bool {
+	_, ok := s.Span(x)
+	return ok
+}
+
+// maximal interval of the set containing x
+func (s *RangeSet[T]) Span(x T) (Interval[T], bool) {
+	if n := floorSpan(s.root, x, true); n != nil && n.span.Contains(x) {
+		return n.span, true
+	}
+	return Interval[T]{}, false
+}
+
+// whether every value of iv is in the set
+func (s *RangeSet[T]) ContainsInterval(iv Interval[T]) bool {
+	if iv.Empty() {
+		return true
+	}
+	span, ok := s.Span(iv.Lo)
+	return ok && iv.Hi <= span.Hi
+}
+
+func (s *RangeSet[T]) Add(iv Interval[T]) {
+	if iv.Empty() {
+		return
+	}
+	l, r := splitSpans(s.root, iv.Lo, false)
+	// of the spans starting before iv, only the last can overlap or touch it
+	if last := lastSpan(l); last != nil && last.span.Hi >= iv.Lo {
+		iv = Interval[T]{last.span.Lo, max(iv.Hi, last.span.Hi)}
+		l = dropLastSpan(l)
+	}
+	// spans starting inside iv, or right at its end, merge into it
+	m, r := splitSpans(r, iv.Hi, true)
+	if last := lastSpan(m); last != nil {
+		iv.Hi = max(iv.Hi, last.span.Hi)
+	}
+	s.root = mergeSpans(mergeSpans(l, newSpanNode(iv, struct{}{})), r)
+}
+
+func (s *RangeSet[T]) Remove(iv Interval[T]) {
+	if iv.Empty() {
+		return
+	}
+	var pieces []Interval[T]
+	l, r := splitSpans(s.root, iv.Lo, false)
+	// the last span starting before iv may stick out on either side of it
+	if last := lastSpan(l); last != nil && last.span.Hi > iv.Lo {
+		pieces = append(pieces, Interval[T]{last.span.Lo, iv.Lo}, Interval[T]{iv.Hi, last.span.Hi})
+		l = dropLastSpan(l)
+	}
+	// spans starting inside iv are dropped, except for whatever the last sticks out past it
+	m, r := splitSpans(r, iv.Hi, false)
+	if last := lastSpan(m); last != nil {
+		pieces = append(pieces, Interval[T]{iv.Hi, last.span.Hi})
+	}
+
+	for _, piece := range pieces {
+		if !piece.Empty() {
+			l = mergeSpans(l, newSpanNode(piece, struct{}{}))
+		}
+	}
+	s.root = mergeSpans(l, r)
+}
+
+// values in either set
+func (s *RangeSet[T]) Union(o *RangeSet[T]) *RangeSet[T] {
+	res := s.Clone()
+	for iv := range o.All() {
+		res.Add(iv)
+	}
+	return res
+}
+
+// values in both sets
+func (s *RangeSet[T]) Intersect(o *RangeSet[T]) *RangeSet[T] {
+	res := &RangeSet[T]{}
+	// both span lists are sorted, so walk them together
+	a, b := s.Intervals(), o.Intervals()
+	for i, j := 0, 0; i < len(a) && j < len(b); {
+		// pieces of disjoint, non-adjacent spans can't touch, so no merging is needed
+		res.appendSpan(a[i].Intersect(b[j]))
+		if a[i].Hi < b[j].Hi {
+			i++
+		} else {
+			j++
+		}
+	}
+	return res
+}
+
+// values in s but not o
+func (s *RangeSet[T]) Difference(o *RangeSet[T]) *RangeSet[T] {
+	res := s.Clone()
+	for iv := range o.All() {
+		res.Remove(iv)
+	}
+	return res
+}
+
+// values within bounds that aren't in the set
+func (s *RangeSet[T]) Complement(bounds Interval[T]) *RangeSet[T] {
+	res := &RangeSet[T]{}
+	lo := bounds.Lo
+	for n := range spansFrom(s.root, bounds.Lo) {
+		if n.span.Lo >= bounds.Hi {
+			break
+		}
+		res.appendSpan(Interval[T]{lo, min(n.span.Lo, bounds.Hi)})
+		lo = n.span.Hi
+	}
+	res.appendSpan(Interval[T]{lo, bounds.Hi})
+	return res
+}
+
+// add an interval that lies after, and doesn't touch, every span in the set
+func (s *RangeSet[T]) appendSpan(iv Interval[T]) {
+	if !iv.Empty() {
+		s.root = mergeSpans(s.root, newSpanNode(iv, struct{}{}))
+	}
+}
+
+// leftmost n values in a row that are all in the set, e.g. the first free block big enough
+// for a file when the set holds free space. returns false for n <= 0, since an empty block
+// has no position to report
+func (s *RangeSet[T]) FirstFit(n T) (Interval[T], bool) {
+	if n <= 0 {
+		return Interval[T]{}, false
+	}
+	if node := firstFitSpan(s.root, n); node != nil {
+		return IntervalOfLen(node.span.Lo, n), true
+	}
+	return Interval[T]{}, false
+}
+
+// piecewise translation of integers: values in a source interval move by that interval's
+// shift, and everything else maps to itself
+type RangeMap[T Integer] struct {
+	// source intervals, which never overlap, each carrying its shift
+	root *spanNode[T, T]
+}
+
+func NewRangeMap[T Integer]() *RangeMap[T] {
+	return &RangeMap[T]{}
+}
+
+// map src onto the interval of the same length starting at dst; panics if src overlaps a
+// source that's already mapped
+func (m *RangeMap[T]) Add(src Interval[T], dst T) {
+	if src.Empty() {
+		return
+	}
+	if n := floorSpan(m.root, src.Hi, false); n != nil && n.span.Overlaps(src) {
+		panic(fmt.Sprintf("range map source %v overlaps %v", src, n.span))
+	}
+	l, r := splitSpans(m.root, src.Lo, false)
+	m.root = mergeSpans(mergeSpans(l, newSpanNode(src, dst-src.Lo)), r)
+}
+
+func (m *RangeMap[T]) Apply(x T) T {
+	if n := floorSpan(m.root, x, true); n != nil && n.span.Contains(x) {
+		return x + n.val
+	}
+	return x
+}
+
+// image of iv, split at the boundaries of the sources it crosses. pieces come out in order
+// of their position in iv, not sorted by where they land
+func (m *RangeMap[T]) MapInterval(iv Interval[T]) []Interval[T] {
+	var res []Interval[T]
+	if iv.Empty() {
+		return res
+	}
+	lo := iv.Lo
+	for n := range spansFrom(m.root, iv.Lo) {
+		if n.span.Lo >= iv.Hi {
+			break
+		}
+		// unmapped stretch before this source
+		if gap := (Interval[T]{lo, n.span.Lo}); !gap.Empty() {
+			res = append(res, gap)
+		}
+		overlap := n.span.Intersect(iv)
+		res = append(res, overlap.Shift(n.val))
+		lo = overlap.Hi
+	}
+	if rest := (Interval[T]{lo, iv.Hi}); !rest.Empty() {
+		res = append(res, rest)
+	}
+	return res
+}
+
+// image of every value in s
+func (m *RangeMap[T]) MapSet(s *RangeSet[T]) *RangeSet[T] {
+	res := &RangeSet[T]{}
+	for iv := range s.All() {
+		for _, piece := range m.MapInterval(iv) {
+			res.Add(piece)
+		}
+	}
+	return res
+}
@@ -0,0 +1,186 @@
+package util
+
+import (
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+// check the set holds exactly the values marked in model, as canonical spans
+func checkRangeSet(t *testing.T, name string, s *RangeSet[int], model func(int) bool, size int) {
+	t.Helper()
+	var want []Interval[int]
+	for x := range size {
+		if !model(x) {
+			continue
+		}
+		if n := len(want); n > 0 && want[n-1].Hi == x {
+			want[n-1].Hi++
+		} else {
+			want = append(want, Interval[int]{x, x + 1})
+		}
+	}
+
+	got := s.Intervals()
+	if !slices.Equal(got, want) {
+		t.Fatalf("%s = %v, want %v", name, got, want)
+	}
+	total := 0
+	for _, iv := range want {
+		total += iv.Len()
+	}
+	if s.Len() != total || s.NumIntervals() != len(want) {
+		t.Fatalf("%s: Len %d, NumIntervals %d, want %d, %d", name, s.Len(), s.NumIntervals(), total, len(want))
+	}
+	for x := -1; x <= size; x++ {
+		if s.Contains(x) != (x >= 0 && x < size && model(x)) {
+			t.Fatalf("%s: Contains(%d) = %v", name, x, s.Contains(x))
+		}
+	}
+	for k := 1; k <= 8; k++ {
+		fit, ok := s.FirstFit(k)
+		i := slices.IndexFunc(want, func(iv Interval[int]) bool { return iv.Len() >= k })
+		if ok != (i >= 0) || ok && fit != IntervalOfLen(want[i].Lo, k) {
+			t.Fatalf("%s: FirstFit(%d) = %v, %v", name, k, fit, ok)
+		}
+	}
+}
+
+func TestRangeSetAgainstModel(t *testing.T) {
+	const size = 80
+	rng := rand.New(rand.NewPCG(1, 2))
+	for range 500 {
+		a, b := NewRangeSet[int](), &RangeSet[int]{}
+		var ma, mb [size]bool
+		for range 20 {
+			lo := rng.IntN(size - 10)
+			iv := IntervalOfLen(lo, rng.IntN(10))
+			add := rng.IntN(3) > 0
+			s, m := a, &ma
+			if rng.IntN(2) == 0 {
+				s, m = b, &mb
+			}
+			if add {
+				s.Add(iv)
+			} else {
+				s.Remove(iv)
+			}
+			for x := iv.Lo; x < iv.Hi; x++ {
+				m[x] = add
+			}
+		}
+
+		checkRangeSet(t, "a", a, func(x int) bool { return ma[x] }, size)
+		checkRangeSet(t, "union", a.Union(b), func(x int) bool { return ma[x] || mb[x] }, size)
+		checkRangeSet(t, "intersect", a.Intersect(b), func(x int) bool { return ma[x] && mb[x] }, size)
+		checkRangeSet(t, "difference", a.Difference(b), func(x int) bool { return ma[x] && !mb[x] }, size)
+		checkRangeSet(t, "complement", a.Complement(Interval[int]{10, 60}), func(x int) bool {
+			return x >= 10 && x < 60 && !ma[x]
+		}, size)
+		// the operations above leave their operands alone
+		checkRangeSet(t, "a after ops", a, func(x int) bool { return ma[x] }, size)
+	}
+}
+
+func TestRangeSetEdits(t *testing.T) {
+	s := NewRangeSet(NewInterval(5, 10), NewInterval(0, 2), NewInterval(2, 3), NewInterval(20, 25))
+	if want := []Interval[int]{{0, 3}, {5, 10}, {20, 25}}; !slices.Equal(s.Intervals(), want) {
+		t.Errorf("Intervals = %v, want %v", s.Intervals(), want)
+	}
+
+	s.Remove(NewInterval(7, 21))
+	if want := []Interval[int]{{0, 3}, {5, 7}, {21, 25}}; !slices.Equal(s.Intervals(), want) {
+		t.Errorf("after Remove = %v, want %v", s.Intervals(), want)
+	}
+	if span, ok := s.Span(22); !ok || span != NewInterval(21, 25) {
+		t.Errorf("Span(22) = %v, %v", span, ok)
+	}
+	if !s.ContainsInterval(NewInterval(5, 7)) || s.ContainsInterval(NewInterval(5, 8)) {
+		t.Error("ContainsInterval wrong")
+	}
+
+	// splitting a span in the middle
+	s.Remove(NewInterval(22, 23))
+	if want := []Interval[int]{{0, 3}, {5, 7}, {21, 22}, {23, 25}}; !slices.Equal(s.Intervals(), want) {
+		t.Errorf("after split = %v, want %v", s.Intervals(), want)
+	}
+
+	// empty intervals are no-ops
+	s.Add(NewInterval(40, 40))
+	s.Remove(NewInterval(6, 5))
+	if s.Len() != 8 {
+		t.Errorf("Len = %d, want 8", s.Len())
+	}
+
+	got := MergeIntervals([]Interval[int]{{3, 5}, {1, 3}, {10, 11}, {4, 8}})
+	if want := []Interval[int]{{1, 8}, {10, 11}}; !slices.Equal(got, want) {
+		t.Errorf("MergeIntervals = %v, want %v", got, want)
+	}
+}
+
+func TestRangeSetFirstFit(t *testing.T) {
+	// free space on a disk like day09's
+	free := NewRangeSet(IntervalOfLen(2, 3), IntervalOfLen(8, 3), IntervalOfLen(12, 3), IntervalOfLen(18, 1))
+	tests := []struct {
+		n    int
+		want Interval[int]
+		ok   bool
+	}{
+		{1, Interval[int]{2, 3}, true},
+		{3, Interval[int]{2, 5}, true},
+		{4, Interval[int]{}, false},
+	}
+	for _, tt := range tests {
+		if got, ok := free.FirstFit(tt.n); got != tt.want || ok != tt.ok {
+			t.Errorf("FirstFit(%d) = %v, %v, want %v, %v", tt.n, got, ok, tt.want, tt.ok)
+		}
+	}
+
+	free.Remove(IntervalOfLen(2, 2))
+	if got, _ := free.FirstFit(2); got != IntervalOfLen(8, 2) {
+		t.Errorf("FirstFit(2) after filling = %v", got)
+	}
+
+	// zero-length requests, and an empty set
+	for _, s := range []*RangeSet[int]{free, NewRangeSet[int](), {}} {
+		for _, n := range []int{0, -1, 1} {
+			if n > 0 && s.Len() > 0 {
+				continue
+			}
+			if got, ok := s.FirstFit(n); ok || got != (Interval[int]{}) {
+				t.Errorf("FirstFit(%d) on %v = %v, %v, want nothing", n, s.Intervals(), got, ok)
+			}
+		}
+	}
+}
+
+func TestRangeMap(t *testing.T) {
+	m := NewRangeMap[int]()
+	m.Add(NewInterval(98, 100), 50)
+	m.Add(NewInterval(50, 98), 52)
+
+	for x, want := range map[int]int{79: 81, 14: 14, 55: 57, 99: 51, 100: 100} {
+		if got := m.Apply(x); got != want {
+			t.Errorf("Apply(%d) = %d, want %d", x, got, want)
+		}
+	}
+
+	got := m.MapInterval(NewInterval(40, 110))
+	want := []Interval[int]{{40, 50}, {52, 100}, {50, 52}, {100, 110}}
+	if !slices.Equal(got, want) {
+		t.Errorf("MapInterval = %v, want %v", got, want)
+	}
+
+	image := m.MapSet(NewRangeSet(NewInterval(79, 93), NewInterval(55, 68)))
+	if want := []Interval[int]{{57, 70}, {81, 95}}; !slices.Equal(image.Intervals(), want) {
+		t.Errorf("MapSet = %v, want %v", image.Intervals(), want)
+	}
+
+	if !panics(func() { m.Add(NewInterval(90, 99), 0) }) {
+		t.Error("overlapping source didn't panic")
+	}
+	m.Add(NewInterval(100, 105), 0)
+	if got := m.Apply(104); got != 4 {
+		t.Errorf("Apply(104) = %d, want 4", got)
+	}
+}
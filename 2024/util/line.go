@@ -0,0 +1,79 @@
+package util
+
+import "iter"
+
+// positions start, start+dir, start+2*dir, ... without end, so callers must break out
+// of the loop (or use one of the bounded variants below)
+func Ray[T Integer](start Vec2[T], dir Vec2[T]) iter.Seq[Vec2[T]] {
+	return func(yield func(Vec2[T]) bool) {
+		for pos := start; yield(pos); pos = pos.Add(dir) {
+		}
+	}
+}
+
+// the first n positions of the ray from start along dir
+func RayN[T Integer](start Vec2[T], dir Vec2[T], n int) iter.Seq[Vec2[T]] {
+	return func(yield func(Vec2[T]) bool) {
+		pos := start
+		for range n {
+			if !yield(pos) {
+				return
+			}
+			pos = pos.Add(dir)
+		}
+	}
+}
+
+// positions of the ray from start along dir, stopping before the first one where stop is true
+func RayUntil[T Integer](start Vec2[T], dir Vec2[T], stop func(Vec2[T]) bool) iter.Seq[Vec2[T]] {
+	return func(yield func(Vec2[T]) bool) {
+		for pos := start; !stop(pos) && yield(pos); pos = pos.Add(dir) {
+		}
+	}
+}
+
+// positions of the ray from start along dir until it leaves the grid
+func RayInGrid[G any](g Grid[G], start Vec2[int], dir Vec2[int]) iter.Seq[Vec2[int]] {
+	return RayUntil(start, dir, func(pos Vec2[int]) bool { return !g.InBounds(pos) })
+}
+
+// step from start along dir (not checking start itself) until reaching a cell whose value
+// satisfies pred. returns the hit position and the number of steps taken to reach it, or
+// false if the ray left the grid first
+func CastUntil[G any](g Grid[G], start Vec2[int], dir Vec2[int], pred func(G) bool) (Vec2[int], int, bool) {
+	steps := 0
+	for pos := range RayInGrid(g, start.Add(dir), dir) {
+		steps++
+		if pred(g.Get(pos)) {
+			return pos, steps, true
+		}
+	}
+	return Vec2[int]{}, 0, false
+}
+
+// all points on the Bresenham line from a to b, both inclusive
+func Line(a Vec2[int], b Vec2[int]) iter.Seq[Vec2[int]] {
+	return func(yield func(Vec2[int]) bool) {
+		d := b.Sub(a)
+		dx, dy := Abs(d[0]), -Abs(d[1])
+		step := d.Sign()
+
+		// err tracks dx + dy scaled so that both axes can be compared in integers
+		err := dx + dy
+		pos := a
+		for {
+			if !yield(pos) || pos == b {
+				return
+			}
+			e2 := 2 * err
+			if e2 >= dy {
+				err += dy
+				pos[0] += step[0]
+			}
+			if e2 <= dx {
+				err += dx
+				pos[1] += step[1]
+			}
+		}
+	}
+}
@@ -0,0 +1,189 @@
+package util
+
+import (
+	"math"
+	"math/big"
+)
+
+type SolutionKind int
+
+const (
+	NoSolution SolutionKind = iota
+	UniqueSolution
+	InfiniteSolutions
+)
+
+// solution set of a linear system
+type LinearSolution struct {
+	Kind SolutionKind
+	// a particular solution, with every free variable set to 0. nil if there's no solution
+	X []*big.Rat
+	// basis of the null space, so every solution is X + t_1*Basis[0] + t_2*Basis[1] + ...
+	// empty unless Kind is InfiniteSolutions
+	Basis [][]*big.Rat
+}
+
+// solve a x = b exactly over the rationals with gaussian elimination. a has one row per
+// equation and one column per variable, and doesn't need to be square
+func SolveLinear[T Integer](a [][]T, b []T) LinearSolution {
+	rows := len(a)
+	cols := 0
+	if rows > 0 {
+		cols = len(a[0])
+	}
+
+	// augmented matrix [a | b]
+	m := make([][]*big.Rat, rows)
+	for i := range a {
+		m[i] = make([]*big.Rat, cols+1)
+		for j := range cols {
+			m[i][j] = big.NewRat(int64(a[i][j]), 1)
+		}
+		m[i][cols] = big.NewRat(int64(b[i]), 1)
+	}
+
+	// reduce to reduced row echelon form, remembering which column each row's pivot is in
+	var pivotCols []int
+	r := 0
+	for c := 0; c < cols && r < rows; c++ {
+		pivot := -1
+		for i := r; i < rows; i++ {
+			if m[i][c].Sign() != 0 {
+				pivot = i
+				break
+			}
+		}
+		if pivot == -1 {
+			continue
+		}
+		m[r], m[pivot] = m[pivot], m[r]
+
+		inv := new(big.Rat).Inv(m[r][c])
+		for j := c; j <= cols; j++ {
+			m[r][j].Mul(m[r][j], inv)
+		}
+		for i := range rows {
+			if i == r || m[i][c].Sign() == 0 {
+				continue
+			}
+			factor := new(big.Rat).Set(m[i][c])
+			for j := c; j <= cols; j++ {
+				m[i][j].Sub(m[i][j], new(big.Rat).Mul(factor, m[r][j]))
+			}
+		}
+		pivotCols = append(pivotCols, c)
+		r++
+	}
+
+	// a leftover row reading 0 = nonzero means the system is inconsistent
+	for i := r; i < rows; i++ {
+		if m[i][cols].Sign() != 0 {
+			return LinearSolution{Kind: NoSolution}
+		}
+	}
+
+	res := LinearSolution{Kind: UniqueSolution, X: make([]*big.Rat, cols)}
+	isPivot := make([]bool, cols)
+	for j := range cols {
+		res.X[j] = new(big.Rat)
+	}
+	for i, c := range pivotCols {
+		res.X[c].Set(m[i][cols])
+		isPivot[c] = true
+	}
+
+	// each free variable contributes one basis vector: set it to 1 and solve for the pivots
+	for f := range cols {
+		if isPivot[f] {
+			continue
+		}
+		res.Kind = InfiniteSolutions
+		v := make([]*big.Rat, cols)
+		for j := range cols {
+			v[j] = new(big.Rat)
+		}
+		v[f].SetInt64(1)
+		for i, c := range pivotCols {
+			v[c].Neg(m[i][f])
+		}
+		res.Basis = append(res.Basis, v)
+	}
+
+	return res
+}
+
+// find non-negative integers x, y with x*a + y*b = target minimizing costA*x + costB*y, or
+// false if there are none. costs must be non-negative. handles parallel a and b, where
+// there can be many solutions to choose between
+func MinCostNonNegative2(a Vec2[int64], b Vec2[int64], target Vec2[int64], costA int64, costB int64) (int64, int64, bool) {
+	sol := SolveLinear([][]int64{{a[0], b[0]}, {a[1], b[1]}}, target[:])
+	switch sol.Kind {
+	case NoSolution:
+		return 0, 0, false
+	case UniqueSolution:
+		x, y := sol.X[0], sol.X[1]
+		if !x.IsInt() || !y.IsInt() || x.Sign() < 0 || y.Sign() < 0 {
+			return 0, 0, false
+		}
+		return x.Num().Int64(), y.Num().Int64(), true
+	}
+
+	// a and b are parallel and the system is consistent, so every row is a multiple of the
+	// same equation x*p + y*q = t and any nonzero row will do
+	row := 0
+	if a[0] == 0 && b[0] == 0 {
+		row = 1
+	}
+	p, q, t := a[row], b[row], target[row]
+	if p == 0 && q == 0 {
+		// a = b = 0 and target = 0
+		return 0, 0, true
+	}
+
+	g, xg, yg := ExtGCD(p, q)
+	if t%g != 0 {
+		return 0, 0, false
+	}
+	// integer solutions are (x0 + k*dx, y0 + k*dy) for any integer k
+	x0, y0 := MustMul(xg, t/g), MustMul(yg, t/g)
+	dx, dy := q/g, -p/g
+
+	// x, y >= 0 bound k from one side each (or not at all if that step is 0)
+	lo, hi := int64(math.MinInt64), int64(math.MaxInt64)
+	for _, bound := range [][2]int64{{x0, dx}, {y0, dy}} {
+		base, step := bound[0], bound[1]
+		switch {
+		case step > 0:
+			// base + k*step >= 0  <=>  k >= ceil(-base / step)
+			lo = max(lo, -floorDiv(base, step))
+		case step < 0:
+			hi = min(hi, floorDiv(base, -step))
+		case base < 0:
+			return 0, 0, false
+		}
+	}
+	if lo > hi {
+		return 0, 0, false
+	}
+
+	// cost is linear in k, so the minimum is at whichever end the slope points towards
+	slope := MustAdd(MustMul(costA, dx), MustMul(costB, dy))
+	k := lo
+	if slope < 0 || lo == math.MinInt64 {
+		k = hi
+	}
+	if k == math.MinInt64 || k == math.MaxInt64 {
+		// only possible with a negative cost
+		return 0, 0, false
+	}
+	return MustAdd(x0, MustMul(k, dx)), MustAdd(y0, MustMul(k, dy)), true
+}
+
+// a / b rounded towards negative infinity, for b > 0
+func floorDiv(a int64, b int64) int64 {
+	q := a / b
+	if a%b != 0 && a < 0 {
+		q--
+	}
+	return q
+}
@@ -0,0 +1,147 @@
+package util
+
+import (
+	"math/big"
+	"math/rand/v2"
+	"testing"
+)
+
+// a x as rationals
+func matVec[T Integer](a [][]T, x []*big.Rat) []*big.Rat {
+	res := make([]*big.Rat, len(a))
+	for i, row := range a {
+		res[i] = new(big.Rat)
+		for j, v := range row {
+			res[i].Add(res[i], new(big.Rat).Mul(big.NewRat(int64(v), 1), x[j]))
+		}
+	}
+	return res
+}
+
+func ratsEqualInts[T Integer](rats []*big.Rat, ints []T) bool {
+	for i := range rats {
+		if rats[i].Cmp(big.NewRat(int64(ints[i]), 1)) != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func TestSolveLinear(t *testing.T) {
+	tests := []struct {
+		name  string
+		a     [][]int64
+		b     []int64
+		kind  SolutionKind
+		basis int
+	}{
+		{"unique", [][]int64{{94, 22}, {34, 67}}, []int64{8400, 5400}, UniqueSolution, 0},
+		{"unique non-integer", [][]int64{{2, 0}, {0, 3}}, []int64{1, 1}, UniqueSolution, 0},
+		{"overdetermined", [][]int64{{1, 0}, {0, 1}, {1, 1}}, []int64{2, 3, 5}, UniqueSolution, 0},
+		{"inconsistent", [][]int64{{1, 2}, {2, 4}}, []int64{3, 7}, NoSolution, 0},
+		{"overdetermined inconsistent", [][]int64{{1, 0}, {0, 1}, {1, 1}}, []int64{2, 3, 6}, NoSolution, 0},
+		{"parallel", [][]int64{{1, 2}, {2, 4}}, []int64{5, 10}, InfiniteSolutions, 1},
+		{"underdetermined", [][]int64{{1, 1, 1}}, []int64{6}, InfiniteSolutions, 2},
+		{"zero matrix", [][]int64{{0, 0}, {0, 0}}, []int64{0, 0}, InfiniteSolutions, 2},
+	}
+	for _, tt := range tests {
+		sol := SolveLinear(tt.a, tt.b)
+		if sol.Kind != tt.kind {
+			t.Errorf("%s: kind %d, want %d", tt.name, sol.Kind, tt.kind)
+			continue
+		}
+		if tt.kind == NoSolution {
+			if sol.X != nil {
+				t.Errorf("%s: X = %v, want nil", tt.name, sol.X)
+			}
+			continue
+		}
+		if !ratsEqualInts(matVec(tt.a, sol.X), tt.b) {
+			t.Errorf("%s: X = %v doesn't solve the system", tt.name, sol.X)
+		}
+		if len(sol.Basis) != tt.basis {
+			t.Errorf("%s: basis %v, want %d vectors", tt.name, sol.Basis, tt.basis)
+		}
+		zeros := make([]int64, len(tt.a))
+		for _, v := range sol.Basis {
+			if !ratsEqualInts(matVec(tt.a, v), zeros) {
+				t.Errorf("%s: basis vector %v not in the null space", tt.name, v)
+			}
+		}
+	}
+
+	// the exact values of a unique solution
+	sol := SolveLinear([][]int64{{2, 0}, {0, 3}}, []int64{1, 1})
+	if sol.X[0].Cmp(big.NewRat(1, 2)) != 0 || sol.X[1].Cmp(big.NewRat(1, 3)) != 0 {
+		t.Errorf("X = %v, want [1/2 1/3]", sol.X)
+	}
+}
+
+func TestMinCostNonNegative2(t *testing.T) {
+	tests := []struct {
+		name         string
+		a, b, target Vec2[int64]
+		costA, costB int64
+		x, y         int64
+		ok           bool
+	}{
+		{"unique", Vec2[int64]{94, 34}, Vec2[int64]{22, 67}, Vec2[int64]{8400, 5400}, 3, 1, 80, 40, true},
+		{"unique non-integer", Vec2[int64]{26, 66}, Vec2[int64]{67, 21}, Vec2[int64]{12748, 12176}, 3, 1, 0, 0, false},
+		{"unique negative", Vec2[int64]{1, 0}, Vec2[int64]{0, 1}, Vec2[int64]{-1, 3}, 3, 1, 0, 0, false},
+		// x + 2y = 5: (5, 0), (3, 1) or (1, 2)
+		{"parallel, lo optimal", Vec2[int64]{1, 2}, Vec2[int64]{2, 4}, Vec2[int64]{5, 10}, 3, 1, 1, 2, true},
+		{"parallel, hi optimal", Vec2[int64]{1, 2}, Vec2[int64]{2, 4}, Vec2[int64]{5, 10}, 1, 3, 5, 0, true},
+		{"parallel, gcd doesn't divide", Vec2[int64]{2, 4}, Vec2[int64]{4, 8}, Vec2[int64]{3, 6}, 3, 1, 0, 0, false},
+		{"parallel, only negative", Vec2[int64]{1, 1}, Vec2[int64]{2, 2}, Vec2[int64]{-3, -3}, 3, 1, 0, 0, false},
+		{"parallel, opposite directions", Vec2[int64]{2, 2}, Vec2[int64]{-3, -3}, Vec2[int64]{1, 1}, 1, 1, 2, 1, true},
+		{"parallel, first row zero", Vec2[int64]{0, 1}, Vec2[int64]{0, 2}, Vec2[int64]{0, 4}, 3, 1, 0, 2, true},
+		{"a = 0", Vec2[int64]{0, 0}, Vec2[int64]{2, 3}, Vec2[int64]{4, 6}, 3, 1, 0, 2, true},
+		{"a = 0, unreachable", Vec2[int64]{0, 0}, Vec2[int64]{2, 3}, Vec2[int64]{5, 6}, 3, 1, 0, 0, false},
+		{"a = b = target = 0", Vec2[int64]{0, 0}, Vec2[int64]{0, 0}, Vec2[int64]{0, 0}, 3, 1, 0, 0, true},
+		{"a = b = 0", Vec2[int64]{0, 0}, Vec2[int64]{0, 0}, Vec2[int64]{1, 0}, 3, 1, 0, 0, false},
+	}
+	for _, tt := range tests {
+		x, y, ok := MinCostNonNegative2(tt.a, tt.b, tt.target, tt.costA, tt.costB)
+		if x != tt.x || y != tt.y || ok != tt.ok {
+			t.Errorf("%s: got %d, %d, %v, want %d, %d, %v", tt.name, x, y, ok, tt.x, tt.y, tt.ok)
+		}
+	}
+}
+
+func TestMinCostNonNegative2BruteForce(t *testing.T) {
+	// known solutions have x, y < 10 and components are at most 6 in size, so |target| <= 120.
+	// any other solution with y below the known one then has x <= 120 + 6*10, and vice versa,
+	// which bounds where a cheaper solution can be
+	const limit = 200
+	rng := rand.New(rand.NewPCG(3, 4))
+	small := func() int64 { return rng.Int64N(9) - 2 }
+	for range 2000 {
+		a := Vec2[int64]{small(), small()}
+		b := a.Mul(small())
+		if rng.IntN(3) == 0 {
+			b = Vec2[int64]{small(), small()}
+		}
+		x, y := rng.Int64N(10), rng.Int64N(10)
+		target := a.Mul(x).Add(b.Mul(y))
+		costA, costB := rng.Int64N(5), rng.Int64N(5)
+
+		best, found := int64(-1), false
+		for x := range int64(limit) {
+			for y := range int64(limit) {
+				if a.Mul(x).Add(b.Mul(y)) == target {
+					if cost := costA*x + costB*y; !found || cost < best {
+						best, found = cost, true
+					}
+				}
+			}
+		}
+
+		gx, gy, ok := MinCostNonNegative2(a, b, target, costA, costB)
+		if ok != found {
+			t.Fatalf("%v %v %v costs %d %d: ok = %v, want %v", a, b, target, costA, costB, ok, found)
+		}
+		if ok && (gx < 0 || gy < 0 || a.Mul(gx).Add(b.Mul(gy)) != target || costA*gx+costB*gy != best) {
+			t.Fatalf("%v %v %v costs %d %d: got %d, %d at cost %d, want cost %d", a, b, target, costA, costB, gx, gy, costA*gx+costB*gy, best)
+		}
+	}
+}
@@ -0,0 +1,71 @@
+package util
+
+import "container/list"
+
+// a recursive function from K to V with cached results
+//
+// multiple arguments can be memoized by packing them into a struct key
+type Memo[K comparable, V any] struct {
+	f     func(recurse func(K) V, k K) V
+	limit int
+	cache map[K]*list.Element
+	// cached entries, most recently used at the front
+	order *list.List
+	stats MemoStats
+}
+
+type MemoStats struct {
+	Hits      int
+	Misses    int
+	Evictions int
+}
+
+type memoEntry[K comparable, V any] struct {
+	key K
+	val V
+}
+
+// wrap f so its results are cached by argument. f is passed a recurse callback to use
+// instead of calling itself, so that recursive calls hit the cache too
+//
+// limit caps the number of cached results, evicting the least recently used; 0 means unlimited
+func NewMemo[K comparable, V any](limit int, f func(recurse func(K) V, k K) V) *Memo[K, V] {
+	return &Memo[K, V]{
+		f:     f,
+		limit: limit,
+		cache: make(map[K]*list.Element),
+		order: list.New(),
+	}
+}
+
+func (m *Memo[K, V]) Get(k K) V {
+	if e, ok := m.cache[k]; ok {
+		m.stats.Hits++
+		m.order.MoveToFront(e)
+		return e.Value.(memoEntry[K, V]).val
+	}
+
+	m.stats.Misses++
+	v := m.f(m.Get, k)
+
+	// the recursion may have cached k already, e.g. if f reaches k again via other arguments
+	if e, ok := m.cache[k]; ok {
+		m.order.Remove(e)
+	}
+	m.cache[k] = m.order.PushFront(memoEntry[K, V]{k, v})
+	if m.limit > 0 && m.order.Len() > m.limit {
+		oldest := m.order.Back()
+		m.order.Remove(oldest)
+		delete(m.cache, oldest.Value.(memoEntry[K, V]).key)
+		m.stats.Evictions++
+	}
+	return v
+}
+
+func (m *Memo[K, V]) Len() int {
+	return len(m.cache)
+}
+
+func (m *Memo[K, V]) Stats() MemoStats {
+	return m.stats
+}
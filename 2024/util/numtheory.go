@@ -0,0 +1,114 @@
+package util
+
+import (
+	"fmt"
+	"math/bits"
+)
+
+// greatest common divisor of |a| and |b|, with GCD(0, 0) = 0
+func GCD[T Integer](a, b T) T {
+	a, b = absInt(a), absInt(b)
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a
+}
+
+// least common multiple of |a| and |b|, with LCM(0, x) = 0
+func LCM[T Integer](a, b T) T {
+	if a == 0 || b == 0 {
+		return 0
+	}
+	return absInt(a / GCD(a, b) * b)
+}
+
+// extended euclidean algorithm: returns g = GCD(a, b) and x, y such that a*x + b*y = g
+func ExtGCD[T Integer](a, b T) (T, T, T) {
+	oldR, r := a, b
+	oldX, x := T(1), T(0)
+	oldY, y := T(0), T(1)
+	for r != 0 {
+		q := oldR / r
+		oldR, r = r, oldR-q*r
+		oldX, x = x, oldX-q*x
+		oldY, y = y, oldY-q*y
+	}
+	if oldR < 0 {
+		return -oldR, -oldX, -oldY
+	}
+	return oldR, oldX, oldY
+}
+
+// a mod m in [0, m) for positive m, unlike % which keeps the sign of a
+func Mod[T Integer](a T, m T) T {
+	// adding m to a negative remainder can't overflow, unlike adding it before reducing again
+	r := a % m
+	if r < 0 {
+		r += m
+	}
+	return r
+}
+
+// x in [0, m) such that a*x = 1 mod m, or false if a and m aren't coprime
+func ModInverse[T Integer](a T, m T) (T, bool) {
+	g, x, _ := ExtGCD(Mod(a, m), m)
+	if g != 1 {
+		return 0, false
+	}
+	return Mod(x, m), true
+}
+
+// b^e mod m for e >= 0, without overflowing even when m is close to the max int64
+func ModPow[T Integer](b T, e T, m T) T {
+	res := Mod(1, m)
+	b = Mod(b, m)
+	for e > 0 {
+		if e&1 == 1 {
+			res = mulMod(res, b, m)
+		}
+		b = mulMod(b, b, m)
+		e >>= 1
+	}
+	return res
+}
+
+// a*b mod m for a, b in [0, m), computed in 128 bits
+func mulMod[T Integer](a T, b T, m T) T {
+	hi, lo := bits.Mul64(uint64(a), uint64(b))
+	return T(bits.Rem64(hi, lo, uint64(m)))
+}
+
+// a+b mod m for a, b in [0, m), without overflowing
+func addMod[T Integer](a T, b T, m T) T {
+	if a >= m-b {
+		return a - (m - b)
+	}
+	return a + b
+}
+
+// solve the system x = residues[i] mod moduli[i] with the chinese remainder theorem. moduli
+// don't need to be coprime. returns the smallest non-negative solution x and the modulus m
+// of the combined congruence (which must fit in T), so every solution is x + k*m, or an
+// error if the system has no solution
+func CRT[T Integer](residues []T, moduli []T) (T, T, error) {
+	x, m := T(0), T(1)
+	for i := range residues {
+		a, n := Mod(residues[i], moduli[i]), moduli[i]
+
+		// x + m*k = a mod n  <=>  m*k = a-x mod n, which is solvable iff g | a-x
+		g := GCD(m, n)
+		diff := Mod(a-x, n)
+		if diff%g != 0 {
+			return 0, 0, fmt.Errorf("congruence x = %d mod %d is inconsistent with x = %d mod %d", a, n, x, m)
+		}
+
+		reduced := n / g
+		inv, _ := ModInverse(m/g, reduced)
+		k := mulMod(Mod(diff/g, reduced), inv, reduced)
+
+		lcm := m * reduced
+		x = addMod(x, mulMod(m, k, lcm), lcm)
+		m = lcm
+	}
+	return x, m, nil
+}
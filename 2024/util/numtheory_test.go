@@ -0,0 +1,97 @@
+package util
+
+import (
+	"math"
+	"math/big"
+	"testing"
+)
+
+func TestMod(t *testing.T) {
+	tests := []struct {
+		a, m, want int64
+	}{
+		{7, 3, 1},
+		{-7, 3, 2},
+		{-6, 3, 0},
+		{0, 5, 0},
+		{math.MaxInt64 - 2, math.MaxInt64 - 1, math.MaxInt64 - 2},
+		{-1, math.MaxInt64, math.MaxInt64 - 1},
+		{math.MinInt64, math.MaxInt64, math.MaxInt64 - 1},
+		{math.MaxInt64, math.MaxInt64, 0},
+	}
+	for _, tt := range tests {
+		if got := Mod(tt.a, tt.m); got != tt.want {
+			t.Errorf("Mod(%d, %d) = %d, want %d", tt.a, tt.m, got, tt.want)
+		}
+	}
+}
+
+func TestModPowLargeModulus(t *testing.T) {
+	tests := []struct {
+		b, e, m int64
+	}{
+		{2, 10, 1000},
+		{-3, 5, 7},
+		{math.MaxInt64 - 2, 3, math.MaxInt64 - 1},
+		{-5, 1 << 40, math.MaxInt64},
+		{123456789, 987654321, 1<<62 + 1},
+		{5, 0, 1},
+	}
+	for _, tt := range tests {
+		m := big.NewInt(tt.m)
+		b := new(big.Int).Mod(big.NewInt(tt.b), m)
+		want := new(big.Int).Exp(b, big.NewInt(tt.e), m).Int64()
+		if got := ModPow(tt.b, tt.e, tt.m); got != want {
+			t.Errorf("ModPow(%d, %d, %d) = %d, want %d", tt.b, tt.e, tt.m, got, want)
+		}
+	}
+}
+
+func TestModInverse(t *testing.T) {
+	if got, ok := ModInverse(3, 11); !ok || got != 4 {
+		t.Errorf("ModInverse(3, 11) = %d, %v", got, ok)
+	}
+	if got, ok := ModInverse(-3, 11); !ok || got != 7 {
+		t.Errorf("ModInverse(-3, 11) = %d, %v", got, ok)
+	}
+	if _, ok := ModInverse(4, 10); ok {
+		t.Error("ModInverse(4, 10) should fail")
+	}
+}
+
+func TestCRT(t *testing.T) {
+	tests := []struct {
+		residues, moduli []int64
+		x, m             int64
+		ok               bool
+	}{
+		{[]int64{2, 3, 2}, []int64{3, 5, 7}, 23, 105, true},
+		{[]int64{-1, -1}, []int64{4, 6}, 11, 12, true},
+		// non-coprime moduli that disagree
+		{[]int64{1, 2}, []int64{4, 6}, 0, 0, false},
+		// combined modulus close to the max int64
+		{[]int64{1, 2}, []int64{math.MaxInt64 / 2, 2}, 1 << 62, math.MaxInt64 - 1, true},
+		{[]int64{3037000000, 5}, []int64{3037000493, 3037000453}, 0, 3037000493 * 3037000453, true},
+	}
+	for _, tt := range tests {
+		x, m, err := CRT(tt.residues, tt.moduli)
+		if (err == nil) != tt.ok {
+			t.Errorf("CRT(%v, %v) error = %v", tt.residues, tt.moduli, err)
+			continue
+		}
+		if !tt.ok {
+			continue
+		}
+		if m != tt.m {
+			t.Errorf("CRT(%v, %v) modulus = %d, want %d", tt.residues, tt.moduli, m, tt.m)
+		}
+		if tt.x != 0 && x != tt.x {
+			t.Errorf("CRT(%v, %v) = %d, want %d", tt.residues, tt.moduli, x, tt.x)
+		}
+		for i := range tt.residues {
+			if Mod(x, tt.moduli[i]) != Mod(tt.residues[i], tt.moduli[i]) {
+				t.Errorf("CRT(%v, %v) = %d fails congruence %d", tt.residues, tt.moduli, x, i)
+			}
+		}
+	}
+}
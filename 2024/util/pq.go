@@ -0,0 +1,141 @@
+package util
+
+import "cmp"
+
+// binary heap of distinct keys, each with a priority that can change while it's queued
+//
+// keys identify items independently of their priority, so updating a priority never
+// requires knowing the old one
+type PriorityQueue[K comparable, P cmp.Ordered] struct {
+	items   []pqItem[K, P]
+	indices map[K]int
+	before  func(a, b P) bool
+}
+
+type pqItem[K comparable, P cmp.Ordered] struct {
+	key      K
+	priority P
+}
+
+// queue that pops the lowest priority first
+func NewMinPriorityQueue[K comparable, P cmp.Ordered]() *PriorityQueue[K, P] {
+	return newPriorityQueue[K](func(a, b P) bool { return a < b })
+}
+
+// queue that pops the highest priority first
+func NewMaxPriorityQueue[K comparable, P cmp.Ordered]() *PriorityQueue[K, P] {
+	return newPriorityQueue[K](func(a, b P) bool { return a > b })
+}
+
+func newPriorityQueue[K comparable, P cmp.Ordered](before func(a, b P) bool) *PriorityQueue[K, P] {
+	return &PriorityQueue[K, P]{
+		indices: make(map[K]int),
+		before:  before,
+	}
+}
+
+func (pq *PriorityQueue[K, P]) Len() int {
+	return len(pq.items)
+}
+
+func (pq *PriorityQueue[K, P]) Contains(key K) bool {
+	_, ok := pq.indices[key]
+	return ok
+}
+
+// current priority of a queued key
+func (pq *PriorityQueue[K, P]) Priority(key K) (P, bool) {
+	i, ok := pq.indices[key]
+	if !ok {
+		var zero P
+		return zero, false
+	}
+	return pq.items[i].priority, true
+}
+
+// add key to the queue, or change its priority if it's already queued
+func (pq *PriorityQueue[K, P]) Push(key K, priority P) {
+	if i, ok := pq.indices[key]; ok {
+		pq.items[i].priority = priority
+		pq.fix(i)
+		return
+	}
+
+	pq.items = append(pq.items, pqItem[K, P]{key, priority})
+	pq.indices[key] = len(pq.items) - 1
+	pq.up(len(pq.items) - 1)
+}
+
+// move a queued key towards the front, i.e. lower its priority in a min queue or raise it in a
+// max queue. returns false (and does nothing) if key isn't queued or priority isn't an improvement
+func (pq *PriorityQueue[K, P]) DecreaseKey(key K, priority P) bool {
+	i, ok := pq.indices[key]
+	if !ok || !pq.before(priority, pq.items[i].priority) {
+		return false
+	}
+	pq.items[i].priority = priority
+	pq.up(i)
+	return true
+}
+
+// front of the queue without removing it; panics if the queue is empty
+func (pq *PriorityQueue[K, P]) Peek() (K, P) {
+	return pq.items[0].key, pq.items[0].priority
+}
+
+// remove and return the front of the queue; panics if the queue is empty
+func (pq *PriorityQueue[K, P]) Pop() (K, P) {
+	front := pq.items[0]
+	last := len(pq.items) - 1
+	pq.swap(0, last)
+	pq.items = pq.items[:last]
+	delete(pq.indices, front.key)
+	if last > 0 {
+		pq.down(0)
+	}
+	return front.key, front.priority
+}
+
+func (pq *PriorityQueue[K, P]) swap(i, j int) {
+	pq.items[i], pq.items[j] = pq.items[j], pq.items[i]
+	pq.indices[pq.items[i].key] = i
+	pq.indices[pq.items[j].key] = j
+}
+
+func (pq *PriorityQueue[K, P]) fix(i int) {
+	if !pq.up(i) {
+		pq.down(i)
+	}
+}
+
+// sift item i towards the root, returning whether it moved
+func (pq *PriorityQueue[K, P]) up(i int) bool {
+	moved := false
+	for i > 0 {
+		parent := (i - 1) / 2
+		if !pq.before(pq.items[i].priority, pq.items[parent].priority) {
+			break
+		}
+		pq.swap(i, parent)
+		i = parent
+		moved = true
+	}
+	return moved
+}
+
+func (pq *PriorityQueue[K, P]) down(i int) {
+	n := len(pq.items)
+	for {
+		first := i
+		for _, child := range []int{2*i + 1, 2*i + 2} {
+			if child < n && pq.before(pq.items[child].priority, pq.items[first].priority) {
+				first = child
+			}
+		}
+		if first == i {
+			return
+		}
+		pq.swap(i, first)
+		i = first
+	}
+}
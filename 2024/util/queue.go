@@ -0,0 +1,42 @@
+package util
+
+// FIFO queue backed by a ring buffer, so popping never leaks the front of the backing array
+type Queue[T any] struct {
+	buf  []T
+	head int
+	size int
+}
+
+func (q *Queue[T]) Len() int {
+	return q.size
+}
+
+func (q *Queue[T]) Push(v T) {
+	if q.size == len(q.buf) {
+		q.grow()
+	}
+	q.buf[(q.head+q.size)%len(q.buf)] = v
+	q.size++
+}
+
+// remove and return the front of the queue; panics if the queue is empty
+func (q *Queue[T]) Pop() T {
+	if q.size == 0 {
+		panic("pop from empty queue")
+	}
+	v := q.buf[q.head]
+	var zero T
+	q.buf[q.head] = zero
+	q.head = (q.head + 1) % len(q.buf)
+	q.size--
+	return v
+}
+
+func (q *Queue[T]) grow() {
+	buf := make([]T, max(16, 2*len(q.buf)))
+	for i := range q.size {
+		buf[i] = q.buf[(q.head+i)%len(q.buf)]
+	}
+	q.buf = buf
+	q.head = 0
+}
@@ -0,0 +1,114 @@
+package util
+
+// which neighbors count as connected when labeling regions
+type Connectivity int
+
+const (
+	Connect4 Connectivity = iota
+	Connect8
+)
+
+func (c Connectivity) directions() []Vec2[int] {
+	if c == Connect8 {
+		return Directions8
+	}
+	return Directions4
+}
+
+// a connected component of a grid
+//
+// perimeter, sides and boundary are always measured along orthogonal edges, so for
+// Connect8 regions a diagonal pinch point is treated as two separate corners
+type Region struct {
+	Label     int
+	Cells     []Vec2[int]
+	Area      int
+	Perimeter int
+	Sides     int
+	// bounding box, inclusive on both ends
+	Min Vec2[int]
+	Max Vec2[int]
+	// cells with at least one orthogonal neighbor outside the region
+	Boundary Set[Vec2[int]]
+}
+
+// equality predicate for grids whose regions are runs of identical values
+func Equal[T comparable](a, b T) bool {
+	return a == b
+}
+
+// label the connected components of the grid, where adjacent cells a and b are connected
+// iff same(a, b). returns a grid of region labels (indices into the returned slice)
+func LabelRegions[T any](g Grid[T], same func(a, b T) bool, conn Connectivity) (Grid[int], []Region) {
+	labels := make(Grid[int], len(g))
+	for r := range g {
+		labels[r] = make([]int, len(g[r]))
+		for c := range labels[r] {
+			labels[r][c] = -1
+		}
+	}
+
+	var regions []Region
+	var stack []Vec2[int]
+	for r := range g {
+		for c := range g[r] {
+			if labels[r][c] != -1 {
+				continue
+			}
+
+			// flood fill iteratively so large regions can't overflow the stack
+			label := len(regions)
+			region := Region{Label: label, Min: Vec2[int]{r, c}, Max: Vec2[int]{r, c}}
+			labels[r][c] = label
+			stack = append(stack[:0], Vec2[int]{r, c})
+			for len(stack) > 0 {
+				pos := stack[len(stack)-1]
+				stack = stack[:len(stack)-1]
+				region.Cells = append(region.Cells, pos)
+				region.Min = region.Min.Min(pos)
+				region.Max = region.Max.Max(pos)
+
+				for _, dir := range conn.directions() {
+					n := pos.Add(dir)
+					if g.InBounds(n) && labels.Get(n) == -1 && same(g.Get(pos), g.Get(n)) {
+						labels.Set(n, label)
+						stack = append(stack, n)
+					}
+				}
+			}
+
+			region.measure(labels)
+			regions = append(regions, region)
+		}
+	}
+
+	return labels, regions
+}
+
+// fill in the area, perimeter, sides and boundary of a region whose cells have been labeled
+func (region *Region) measure(labels Grid[int]) {
+	inRegion := func(pos Vec2[int]) bool {
+		return labels.InBounds(pos) && labels.Get(pos) == region.Label
+	}
+
+	region.Area = len(region.Cells)
+	region.Boundary = make(Set[Vec2[int]])
+	for _, pos := range region.Cells {
+		for i, dir := range Directions4 {
+			if !inRegion(pos.Add(dir)) {
+				region.Perimeter++
+				region.Boundary.Add(pos)
+			}
+
+			// a polygon has as many sides as corners, so count the corners at each cell using
+			// this direction and the next one clockwise:
+			// * external corner if neither orthogonal neighbor is in the region
+			// * internal corner if both are, but the diagonal between them isn't
+			next := Directions4[(i+1)%len(Directions4)]
+			a, b := inRegion(pos.Add(dir)), inRegion(pos.Add(next))
+			if (!a && !b) || (a && b && !inRegion(pos.Add(dir).Add(next))) {
+				region.Sides++
+			}
+		}
+	}
+}
@@ -0,0 +1,154 @@
+package util
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+var intRegex = regexp.MustCompile(`[-+]?\d+`)
+
+// every integer in the string, in order, e.g. Ints("p=0,4 v=3,-3") = [0 4 3 -3]
+//
+// a '-' or '+' right after a digit is read as a separator rather than a sign, so ranges like
+// "2-4,6-8" give [2 4 6 8]
+func Ints(s string) []int {
+	matches := intMatches(s)
+	res := make([]int, len(matches))
+	for i, m := range matches {
+		res[i] = MustAtoi(m)
+	}
+	return res
+}
+
+// same as Ints, but parsed as int64
+func Int64s(s string) []int64 {
+	matches := intMatches(s)
+	res := make([]int64, len(matches))
+	for i, m := range matches {
+		res[i] = MustAtoiInt64(m)
+	}
+	return res
+}
+
+// text of every integer in s, with signs dropped where they follow a digit
+func intMatches(s string) []string {
+	locs := intRegex.FindAllStringIndex(s, -1)
+	matches := make([]string, len(locs))
+	for i, loc := range locs {
+		start := loc[0]
+		if (s[start] == '-' || s[start] == '+') && start > 0 && isDigit(s[start-1]) {
+			start++
+		}
+		matches[i] = s[start:loc[1]]
+	}
+	return matches
+}
+
+func isDigit(b byte) bool {
+	return '0' <= b && b <= '9'
+}
+
+// values captured by Scan, in order. each is a string or an int64 depending on its placeholder
+type Captures []any
+
+// panics if the value doesn't fit in an int
+func (c Captures) Int(i int) int {
+	n := c[i].(int64)
+	if int64(int(n)) != n {
+		panic(fmt.Sprintf("capture %d = %d overflows int", i, n))
+	}
+	return int(n)
+}
+
+func (c Captures) Int64(i int) int64 {
+	return c[i].(int64)
+}
+
+func (c Captures) Str(i int) string {
+	return c[i].(string)
+}
+
+// compiled patterns, keyed by the pattern passed to Scan. guarded by scanPatternsMu so parsers
+// can run in parallel tests
+var (
+	scanPatterns   = make(map[string]*regexp.Regexp)
+	scanPatternsMu sync.Mutex
+)
+
+var placeholderRegex = regexp.MustCompile(`\{(|str|int)\}`)
+
+// match the whole line against a pattern of literal text with placeholders, returning what
+// the placeholders captured:
+// * {int} captures a signed integer
+// * {} or {str} captures any text, as little as possible
+//
+// e.g. Scan("Button A: X+94, Y+34", "Button {}: X+{int}, Y+{int}") = ["A", 94, 34]
+//
+// integers are parsed as int64, so read them back with Captures.Int or Captures.Int64
+func Scan(line string, pattern string) (Captures, error) {
+	re := scanPattern(pattern)
+
+	match := re.FindStringSubmatch(line)
+	if match == nil {
+		return nil, fmt.Errorf("line %q doesn't match pattern %q", line, pattern)
+	}
+
+	placeholders := placeholderRegex.FindAllStringSubmatch(pattern, -1)
+	captures := make(Captures, len(placeholders))
+	for i, p := range placeholders {
+		text := match[i+1]
+		if p[1] != "int" {
+			captures[i] = text
+			continue
+		}
+
+		n, err := strconv.ParseInt(text, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("capture %d of line %q against pattern %q: %w", i, line, pattern, err)
+		}
+		captures[i] = n
+	}
+	return captures, nil
+}
+
+// same as Scan, but panics if the line doesn't match
+func MustScan(line string, pattern string) Captures {
+	c, err := Scan(line, pattern)
+	if err != nil {
+		panic(err)
+	}
+	return c
+}
+
+// compiled regexp for a Scan pattern, compiling it on first use
+func scanPattern(pattern string) *regexp.Regexp {
+	scanPatternsMu.Lock()
+	defer scanPatternsMu.Unlock()
+	re, ok := scanPatterns[pattern]
+	if !ok {
+		re = compileScanPattern(pattern)
+		scanPatterns[pattern] = re
+	}
+	return re
+}
+
+func compileScanPattern(pattern string) *regexp.Regexp {
+	var sb strings.Builder
+	sb.WriteString("^")
+	last := 0
+	for _, loc := range placeholderRegex.FindAllStringSubmatchIndex(pattern, -1) {
+		sb.WriteString(regexp.QuoteMeta(pattern[last:loc[0]]))
+		if pattern[loc[2]:loc[3]] == "int" {
+			sb.WriteString(`([-+]?\d+)`)
+		} else {
+			sb.WriteString(`(.*?)`)
+		}
+		last = loc[1]
+	}
+	sb.WriteString(regexp.QuoteMeta(pattern[last:]))
+	sb.WriteString("$")
+	return regexp.MustCompile(sb.String())
+}
@@ -0,0 +1,77 @@
+package util
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"sync"
+	"testing"
+)
+
+func TestInts(t *testing.T) {
+	tests := []struct {
+		s    string
+		want []int
+	}{
+		{"p=0,4 v=3,-3", []int{0, 4, 3, -3}},
+		{"2-4,6-8", []int{2, 4, 6, 8}},
+		{"x=-5..-2", []int{-5, -2}},
+		{"1 - -2", []int{1, -2}},
+		{"+7 and 8+9", []int{7, 8, 9}},
+		{"-12", []int{-12}},
+		{"no numbers", []int{}},
+	}
+	for _, tt := range tests {
+		if got := Ints(tt.s); !slices.Equal(got, tt.want) {
+			t.Errorf("Ints(%q) = %v, want %v", tt.s, got, tt.want)
+		}
+	}
+
+	if got := Int64s("1-9223372036854775807"); !slices.Equal(got, []int64{1, math.MaxInt64}) {
+		t.Errorf("Int64s = %v", got)
+	}
+}
+
+func TestScan(t *testing.T) {
+	c, err := Scan("Button A: X+94, Y-34", "Button {}: X{int}, Y{int}")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.Str(0) != "A" || c.Int(1) != 94 || c.Int(2) != -34 || c.Int64(2) != -34 {
+		t.Errorf("captures = %v", c)
+	}
+
+	c = MustScan("big 9223372036854775807", "big {int}")
+	if c.Int64(0) != math.MaxInt64 {
+		t.Errorf("Int64 = %d", c.Int64(0))
+	}
+
+	if _, err := Scan("Button A: X+94", "Button {}: X+{int}, Y+{int}"); err == nil {
+		t.Error("mismatched line didn't error")
+	}
+	if _, err := Scan("n = 99999999999999999999", "n = {int}"); err == nil {
+		t.Error("out of range integer didn't error")
+	}
+	if !panics(func() { MustScan("nope", "{int}") }) {
+		t.Error("MustScan didn't panic")
+	}
+}
+
+func TestScanConcurrent(t *testing.T) {
+	var wg sync.WaitGroup
+	for g := range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range 100 {
+				// distinct patterns per goroutine, so the cache is written concurrently too
+				pattern := fmt.Sprintf("g%d i%d: {int}", g, i%10)
+				line := fmt.Sprintf("g%d i%d: %d", g, i%10, i)
+				if c, err := Scan(line, pattern); err != nil || c.Int(0) != i {
+					t.Errorf("Scan(%q, %q) = %v, %v", line, pattern, c, err)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
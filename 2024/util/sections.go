@@ -0,0 +1,71 @@
+package util
+
+import "strings"
+
+// lines of one blank-line-separated block of the input
+type Section []string
+
+// convert CRLF line endings to LF and strip trailing whitespace from every line and from the
+// end of the input, so that nothing downstream has to care how the input was saved
+func NormalizeInput(input string) string {
+	input = strings.ReplaceAll(input, "\r\n", "\n")
+	lines := strings.Split(input, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " \t\r")
+	}
+	return strings.TrimRight(strings.Join(lines, "\n"), "\n")
+}
+
+// non-empty lines of the normalized input
+func Lines(input string) []string {
+	var lines []string
+	for _, line := range strings.Split(NormalizeInput(input), "\n") {
+		if line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+// split the normalized input into blocks separated by one or more blank lines. every block
+// is returned, including the last one, and leading/trailing blank lines don't produce empty blocks
+func Sections(input string) []Section {
+	var sections []Section
+	var current Section
+	for _, line := range strings.Split(NormalizeInput(input), "\n") {
+		if line != "" {
+			current = append(current, line)
+		} else if current != nil {
+			sections = append(sections, current)
+			current = nil
+		}
+	}
+	if current != nil {
+		sections = append(sections, current)
+	}
+	return sections
+}
+
+// the section's lines joined back together, e.g. for NewGridFromString
+func (s Section) Text() string {
+	return strings.Join(s, "\n")
+}
+
+// parse each line of a section
+func ParseLines[T any](s Section, parse func(line string) T) []T {
+	res := make([]T, len(s))
+	for i, line := range s {
+		res[i] = parse(line)
+	}
+	return res
+}
+
+// split the input into sections and parse each one
+func ParseSections[T any](input string, parse func(s Section) T) []T {
+	sections := Sections(input)
+	res := make([]T, len(sections))
+	for i, s := range sections {
+		res[i] = parse(s)
+	}
+	return res
+}
@@ -1,6 +1,14 @@
 package util
 
-import "fmt"
+import (
+	"cmp"
+	"fmt"
+	"iter"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+)
 
 type Set[T comparable] map[T]struct{}
 
@@ -27,24 +35,175 @@ func (s Set[T]) Size() int {
 	return len(s)
 }
 
-func (s Set[T]) String() string {
-	res := "{"
+// iterate over the elements in unspecified order
+func (s Set[T]) All() iter.Seq[T] {
+	return maps.Keys(s)
+}
+
+// always non-nil, even when cloning a nil set, so the clone can be added to
+func (s Set[T]) Clone() Set[T] {
+	res := make(Set[T], len(s))
+	maps.Copy(res, s)
+	return res
+}
+
+// elements in either set
+func (s Set[T]) Union(t Set[T]) Set[T] {
+	res := s.Clone()
+	res.UnionWith(t)
+	return res
+}
 
-	i := 0
-	for key := range s {
-		res += fmt.Sprintf("%v", key)
-		// separator unless last element
-		if i+1 != s.Size() {
-			res += ", "
+// elements in both sets
+func (s Set[T]) Intersect(t Set[T]) Set[T] {
+	// iterate over the smaller set
+	if len(t) < len(s) {
+		s, t = t, s
+	}
+	res := make(Set[T])
+	for v := range s {
+		if t.Has(v) {
+			res.Add(v)
 		}
-		i += 1
 	}
+	return res
+}
 
-	res += "}"
+// elements in s but not t
+func (s Set[T]) Difference(t Set[T]) Set[T] {
+	res := make(Set[T])
+	for v := range s {
+		if !t.Has(v) {
+			res.Add(v)
+		}
+	}
+	return res
+}
 
+// elements in exactly one of the sets
+func (s Set[T]) SymmetricDifference(t Set[T]) Set[T] {
+	res := s.Difference(t)
+	for v := range t {
+		if !s.Has(v) {
+			res.Add(v)
+		}
+	}
 	return res
 }
 
+// add every element of t to s
+func (s Set[T]) UnionWith(t Set[T]) {
+	for v := range t {
+		s.Add(v)
+	}
+}
+
+// remove elements of s that aren't in t
+func (s Set[T]) IntersectWith(t Set[T]) {
+	for v := range s {
+		if !t.Has(v) {
+			delete(s, v)
+		}
+	}
+}
+
+// remove elements of t from s
+func (s Set[T]) DifferenceWith(t Set[T]) {
+	for v := range t {
+		delete(s, v)
+	}
+}
+
+// keep only elements in exactly one of s and t
+func (s Set[T]) SymmetricDifferenceWith(t Set[T]) {
+	for v := range t {
+		if s.Has(v) {
+			delete(s, v)
+		} else {
+			s.Add(v)
+		}
+	}
+}
+
+// whether every element of s is in t
+func (s Set[T]) IsSubset(t Set[T]) bool {
+	if len(s) > len(t) {
+		return false
+	}
+	for v := range s {
+		if !t.Has(v) {
+			return false
+		}
+	}
+	return true
+}
+
+func (s Set[T]) Equal(t Set[T]) bool {
+	return len(s) == len(t) && s.IsSubset(t)
+}
+
+// elements are listed in sorted order so output is deterministic: numbers and strings in
+// their natural order, arrays (e.g. Vec2) and structs field by field, anything else by its
+// formatted value
+func (s Set[T]) String() string {
+	vals := slices.SortedFunc(s.All(), func(a, b T) int {
+		return compareValues(reflect.ValueOf(a), reflect.ValueOf(b))
+	})
+
+	strs := make([]string, len(vals))
+	for i, v := range vals {
+		strs[i] = fmt.Sprintf("%v", v)
+	}
+	return "{" + strings.Join(strs, ", ") + "}"
+}
+
+func compareValues(a, b reflect.Value) int {
+	switch a.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return cmp.Compare(a.Int(), b.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return cmp.Compare(a.Uint(), b.Uint())
+	case reflect.Float32, reflect.Float64:
+		return cmp.Compare(a.Float(), b.Float())
+	case reflect.String:
+		return cmp.Compare(a.String(), b.String())
+	case reflect.Bool:
+		return cmp.Compare(boolToInt(a.Bool()), boolToInt(b.Bool()))
+	case reflect.Array:
+		for i := range a.Len() {
+			if c := compareValues(a.Index(i), b.Index(i)); c != 0 {
+				return c
+			}
+		}
+		return 0
+	case reflect.Struct:
+		for i := range a.NumField() {
+			if c := compareValues(a.Field(i), b.Field(i)); c != 0 {
+				return c
+			}
+		}
+		return 0
+	default:
+		return cmp.Compare(fmt.Sprint(a), fmt.Sprint(b))
+	}
+}
+
+func boolToInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
+
 func CreateSet[T comparable]() Set[T] {
 	return make(Set[T])
 }
+
+// set of the values yielded by seq
+func SetFromSeq[T comparable](seq iter.Seq[T]) Set[T] {
+	s := make(Set[T])
+	for v := range seq {
+		s.Add(v)
+	}
+	return s
+}
@@ -0,0 +1,132 @@
+package util
+
+import (
+	"slices"
+	"testing"
+)
+
+func setOf[T comparable](vals ...T) Set[T] {
+	s := make(Set[T])
+	s.AddAll(vals)
+	return s
+}
+
+func TestSetAlgebra(t *testing.T) {
+	a, b := setOf(1, 2, 3, 4), setOf(3, 4, 5)
+	tests := []struct {
+		name string
+		got  Set[int]
+		want Set[int]
+	}{
+		{"union", a.Union(b), setOf(1, 2, 3, 4, 5)},
+		{"intersect", a.Intersect(b), setOf(3, 4)},
+		{"intersect reversed", b.Intersect(a), setOf(3, 4)},
+		{"difference", a.Difference(b), setOf(1, 2)},
+		{"difference reversed", b.Difference(a), setOf(5)},
+		{"symmetric difference", a.SymmetricDifference(b), setOf(1, 2, 5)},
+		{"union with empty", a.Union(setOf[int]()), a},
+		{"intersect with empty", a.Intersect(setOf[int]()), setOf[int]()},
+	}
+	for _, tt := range tests {
+		if !tt.got.Equal(tt.want) {
+			t.Errorf("%s = %v, want %v", tt.name, tt.got, tt.want)
+		}
+	}
+
+	// the operands are left alone
+	if !a.Equal(setOf(1, 2, 3, 4)) || !b.Equal(setOf(3, 4, 5)) {
+		t.Errorf("operands modified: %v, %v", a, b)
+	}
+}
+
+func TestSetNil(t *testing.T) {
+	var s Set[int]
+	if got := s.Union(setOf(1)); !got.Equal(setOf(1)) {
+		t.Errorf("nil union = %v", got)
+	}
+	if got := s.Clone(); got == nil {
+		t.Error("clone of nil set is nil")
+	}
+	if got := s.Intersect(setOf(1)); got.Size() != 0 {
+		t.Errorf("nil intersect = %v", got)
+	}
+	if got := setOf(1).Difference(s); !got.Equal(setOf(1)) {
+		t.Errorf("difference with nil = %v", got)
+	}
+	if s.Has(1) || !s.IsSubset(setOf(1)) || !s.Equal(setOf[int]()) {
+		t.Error("nil set doesn't behave as empty")
+	}
+}
+
+func TestSetInPlace(t *testing.T) {
+	tests := []struct {
+		name string
+		op   func(s, t Set[int])
+		want Set[int]
+	}{
+		{"UnionWith", Set[int].UnionWith, setOf(1, 2, 3, 4, 5)},
+		{"IntersectWith", Set[int].IntersectWith, setOf(3, 4)},
+		{"DifferenceWith", Set[int].DifferenceWith, setOf(1, 2)},
+		{"SymmetricDifferenceWith", Set[int].SymmetricDifferenceWith, setOf(1, 2, 5)},
+	}
+	for _, tt := range tests {
+		s, other := setOf(1, 2, 3, 4), setOf(3, 4, 5)
+		tt.op(s, other)
+		if !s.Equal(tt.want) {
+			t.Errorf("%s gave %v, want %v", tt.name, s, tt.want)
+		}
+		if !other.Equal(setOf(3, 4, 5)) {
+			t.Errorf("%s modified its argument: %v", tt.name, other)
+		}
+	}
+}
+
+func TestSetSubsetAndEqual(t *testing.T) {
+	a := setOf(1, 2)
+	if !a.IsSubset(setOf(1, 2, 3)) || !a.IsSubset(a) || a.IsSubset(setOf(1, 3)) {
+		t.Error("IsSubset wrong")
+	}
+	if !a.Equal(setOf(2, 1)) || a.Equal(setOf(1, 2, 3)) || a.Equal(setOf(1, 3)) {
+		t.Error("Equal wrong")
+	}
+}
+
+func TestSetFromSeq(t *testing.T) {
+	s := SetFromSeq(slices.Values([]int{3, 1, 3, 2, 1}))
+	if !s.Equal(setOf(1, 2, 3)) {
+		t.Errorf("SetFromSeq = %v", s)
+	}
+	if got := slices.Sorted(s.All()); !slices.Equal(got, []int{1, 2, 3}) {
+		t.Errorf("All = %v", got)
+	}
+}
+
+func TestSetString(t *testing.T) {
+	type pair struct {
+		name string
+		n    int
+	}
+	tests := []struct {
+		got, want string
+	}{
+		{setOf[int]().String(), "{}"},
+		{setOf(10, -3, 2, 7).String(), "{-3, 2, 7, 10}"},
+		{setOf("b", "c", "a").String(), "{a, b, c}"},
+		{setOf(Vec2[int]{1, 0}, Vec2[int]{0, 5}, Vec2[int]{0, 2}).String(), "{[0 2], [0 5], [1 0]}"},
+		{setOf(pair{"b", 1}, pair{"a", 2}, pair{"a", 1}).String(), "{{a 1}, {a 2}, {b 1}}"},
+		{setOf(true, false).String(), "{false, true}"},
+	}
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("String = %s, want %s", tt.got, tt.want)
+		}
+	}
+
+	// map iteration order varies between runs, so check the output doesn't
+	s := setOf(5, 3, 9, 1, 7, 2, 8)
+	for range 20 {
+		if got := s.String(); got != "{1, 2, 3, 5, 7, 8, 9}" {
+			t.Fatalf("String = %s", got)
+		}
+	}
+}
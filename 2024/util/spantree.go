@@ -0,0 +1,180 @@
+package util
+
+import (
+	"iter"
+	"math/rand/v2"
+)
+
+// treap node over disjoint intervals ordered by Lo, each carrying a value. every node caches
+// aggregates of its subtree so lookups, length totals and first-fit searches all run in
+// O(log n) expected time
+type spanNode[T Integer, V any] struct {
+	span  Interval[T]
+	val   V
+	prio  uint64
+	left  *spanNode[T, V]
+	right *spanNode[T, V]
+
+	// number of spans, their total length, and the longest one, over the whole subtree
+	count   int
+	total   T
+	longest T
+}
+
+func newSpanNode[T Integer, V any](span Interval[T], val V) *spanNode[T, V] {
+	n := &spanNode[T, V]{span: span, val: val, prio: rand.Uint64()}
+	n.update()
+	return n
+}
+
+func spanCount[T Integer, V any](n *spanNode[T, V]) int {
+	if n == nil {
+		return 0
+	}
+	return n.count
+}
+
+func spanTotal[T Integer, V any](n *spanNode[T, V]) T {
+	if n == nil {
+		return 0
+	}
+	return n.total
+}
+
+func spanLongest[T Integer, V any](n *spanNode[T, V]) T {
+	if n == nil {
+		return 0
+	}
+	return n.longest
+}
+
+// recompute the aggregates after a child changed
+func (n *spanNode[T, V]) update() {
+	n.count = 1 + spanCount(n.left) + spanCount(n.right)
+	n.total = n.span.Len() + spanTotal(n.left) + spanTotal(n.right)
+	n.longest = max(n.span.Len(), spanLongest(n.left), spanLongest(n.right))
+}
+
+// split into the spans starting before key (or at key too, if orEqual) and the rest
+func splitSpans[T Integer, V any](n *spanNode[T, V], key T, orEqual bool) (*spanNode[T, V], *spanNode[T, V]) {
+	if n == nil {
+		return nil, nil
+	}
+	if n.span.Lo < key || orEqual && n.span.Lo == key {
+		rest, r := splitSpans(n.right, key, orEqual)
+		n.right = rest
+		n.update()
+		return n, r
+	}
+	l, rest := splitSpans(n.left, key, orEqual)
+	n.left = rest
+	n.update()
+	return l, n
+}
+
+// join two trees where every span in l comes before every span in r
+func mergeSpans[T Integer, V any](l, r *spanNode[T, V]) *spanNode[T, V] {
+	switch {
+	case l == nil:
+		return r
+	case r == nil:
+		return l
+	case l.prio > r.prio:
+		l.right = mergeSpans(l.right, r)
+		l.update()
+		return l
+	default:
+		r.left = mergeSpans(l, r.left)
+		r.update()
+		return r
+	}
+}
+
+// last span starting before key (or at key too, if orEqual), or nil
+func floorSpan[T Integer, V any](n *spanNode[T, V], key T, orEqual bool) *spanNode[T, V] {
+	var res *spanNode[T, V]
+	for n != nil {
+		if n.span.Lo < key || orEqual && n.span.Lo == key {
+			res, n = n, n.right
+		} else {
+			n = n.left
+		}
+	}
+	return res
+}
+
+func lastSpan[T Integer, V any](n *spanNode[T, V]) *spanNode[T, V] {
+	for n != nil && n.right != nil {
+		n = n.right
+	}
+	return n
+}
+
+// remove the last span, returning the rest of the tree
+func dropLastSpan[T Integer, V any](n *spanNode[T, V]) *spanNode[T, V] {
+	if n.right == nil {
+		return n.left
+	}
+	n.right = dropLastSpan(n.right)
+	n.update()
+	return n
+}
+
+// leftmost span at least k long, or nil. k must be positive
+func firstFitSpan[T Integer, V any](n *spanNode[T, V], k T) *spanNode[T, V] {
+	if n == nil || spanLongest(n) < k {
+		return nil
+	}
+	for {
+		// the longest span is somewhere below n, so one of these always holds
+		switch {
+		case n.left != nil && n.left.longest >= k:
+			n = n.left
+		case n.span.Len() >= k:
+			return n
+		default:
+			n = n.right
+		}
+	}
+}
+
+// iterate over every span in order
+func allSpans[T Integer, V any](n *spanNode[T, V]) iter.Seq[*spanNode[T, V]] {
+	return func(yield func(*spanNode[T, V]) bool) {
+		var walk func(n *spanNode[T, V]) bool
+		walk = func(n *spanNode[T, V]) bool {
+			return n == nil || walk(n.left) && yield(n) && walk(n.right)
+		}
+		walk(n)
+	}
+}
+
+// iterate in order over the spans ending after from
+func spansFrom[T Integer, V any](n *spanNode[T, V], from T) iter.Seq[*spanNode[T, V]] {
+	return func(yield func(*spanNode[T, V]) bool) {
+		var walk func(n *spanNode[T, V]) bool
+		walk = func(n *spanNode[T, V]) bool {
+			if n == nil {
+				return true
+			}
+			// spans to the left end before this one starts, so if this one ends before from
+			// they all do
+			if n.span.Hi > from {
+				if !walk(n.left) || !yield(n) {
+					return false
+				}
+			}
+			return walk(n.right)
+		}
+		walk(n)
+	}
+}
+
+func cloneSpans[T Integer, V any](n *spanNode[T, V]) *spanNode[T, V] {
+	if n == nil {
+		return nil
+	}
+	c := *n
+	c.left, c.right = cloneSpans(n.left), cloneSpans(n.right)
+	return &c
+}
@@ -0,0 +1,156 @@
+package util
+
+import "iter"
+
+// union-find over arbitrary elements, with path compression and union by rank
+type DisjointSet[T comparable] struct {
+	parent map[T]T
+	rank   map[T]int
+	sets   int
+}
+
+func NewDisjointSet[T comparable]() *DisjointSet[T] {
+	return &DisjointSet[T]{
+		parent: make(map[T]T),
+		rank:   make(map[T]int),
+	}
+}
+
+// add x as a singleton set, returning false if it was already present
+func (d *DisjointSet[T]) Add(x T) bool {
+	if d.Has(x) {
+		return false
+	}
+	d.parent[x] = x
+	d.sets++
+	return true
+}
+
+func (d *DisjointSet[T]) Has(x T) bool {
+	_, ok := d.parent[x]
+	return ok
+}
+
+// representative of the set containing x, adding x first if it isn't present
+func (d *DisjointSet[T]) Find(x T) T {
+	d.Add(x)
+
+	root := x
+	for d.parent[root] != root {
+		root = d.parent[root]
+	}
+	// point everything on the way directly at the root
+	for x != root {
+		x, d.parent[x] = d.parent[x], root
+	}
+	return root
+}
+
+// merge the sets containing a and b, returning false if they were already the same set
+func (d *DisjointSet[T]) Union(a, b T) bool {
+	ra, rb := d.Find(a), d.Find(b)
+	if ra == rb {
+		return false
+	}
+
+	if d.rank[ra] < d.rank[rb] {
+		ra, rb = rb, ra
+	}
+	d.parent[rb] = ra
+	if d.rank[ra] == d.rank[rb] {
+		d.rank[ra]++
+	}
+	d.sets--
+	return true
+}
+
+// whether a and b are both present and in the same set
+func (d *DisjointSet[T]) Connected(a, b T) bool {
+	return d.Has(a) && d.Has(b) && d.Find(a) == d.Find(b)
+}
+
+// number of disjoint sets
+func (d *DisjointSet[T]) Sets() int {
+	return d.sets
+}
+
+// start with the cells in open, then open additions one at a time. returns how many additions it
+// takes before a and b are connected (0 if they already are), or -1 if they never are
+//
+// neighbors can yield cells that aren't open, they're ignored until opened
+func ConnectStep[T comparable](open iter.Seq[T], additions []T, neighbors NeighborFunc[T], a, b T) int {
+	d := NewDisjointSet[T]()
+	for cell := range open {
+		d.Add(cell)
+	}
+	for cell := range d.parent {
+		d.connectOpenNeighbors(cell, neighbors)
+	}
+	if d.Connected(a, b) {
+		return 0
+	}
+
+	for i, cell := range additions {
+		if d.Add(cell) {
+			d.connectOpenNeighbors(cell, neighbors)
+		}
+		if d.Connected(a, b) {
+			return i + 1
+		}
+	}
+	return -1
+}
+
+// start with the cells in open, then close removals one at a time. returns how many removals it
+// takes before a and b are disconnected (0 if they already are), or -1 if they never are
+//
+// this runs the removals backwards as insertions, so it's near-linear instead of needing a
+// fresh search after every removal
+func DisconnectStep[T comparable](open iter.Seq[T], removals []T, neighbors NeighborFunc[T], a, b T) int {
+	// a cell only matters the first time it's removed
+	firstRemoval := make(map[T]int)
+	for i, cell := range removals {
+		if _, ok := firstRemoval[cell]; !ok {
+			firstRemoval[cell] = i
+		}
+	}
+
+	// build the final state, after every removal
+	d := NewDisjointSet[T]()
+	initial := make(Set[T])
+	for cell := range open {
+		initial.Add(cell)
+		if _, removed := firstRemoval[cell]; !removed {
+			d.Add(cell)
+		}
+	}
+	for cell := range d.parent {
+		d.connectOpenNeighbors(cell, neighbors)
+	}
+	if d.Connected(a, b) {
+		return -1
+	}
+
+	// reopen cells from the last removal backwards; once a and b connect, the removal
+	// that was just undone is the one that disconnected them
+	for i := len(removals) - 1; i >= 0; i-- {
+		cell := removals[i]
+		if firstRemoval[cell] != i || !initial.Has(cell) {
+			continue
+		}
+		d.Add(cell)
+		d.connectOpenNeighbors(cell, neighbors)
+		if d.Connected(a, b) {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+func (d *DisjointSet[T]) connectOpenNeighbors(cell T, neighbors NeighborFunc[T]) {
+	for n := range neighbors(cell) {
+		if d.Has(n) {
+			d.Union(cell, n)
+		}
+	}
+}
@@ -1,8 +1,11 @@
 package util
 
-import "strings"
+import (
+	"cmp"
+	"strings"
+)
 
-type Vec2[T ~int | ~int64] [2]T
+type Vec2[T Integer] [2]T
 
 // parse a Vec2[int] from string in format "x,y"
 func NewVec2Int(str string) Vec2[int] {
@@ -25,7 +28,21 @@ func (v Vec2[T]) Mul(c T) Vec2[T] {
 }
 
 func (a Vec2[T]) Sub(b Vec2[T]) Vec2[T] {
-	return a.Add(b.Mul(-1))
+	return a.Add(b.Neg())
+}
+
+func (v Vec2[T]) Neg() Vec2[T] {
+	return Vec2[T]{-v[0], -v[1]}
+}
+
+// component-wise modulo, always non-negative (useful for wrapping around a torus)
+func (a Vec2[T]) Mod(m Vec2[T]) Vec2[T] {
+	return Vec2[T]{Mod(a[0], m[0]), Mod(a[1], m[1])}
+}
+
+// component-wise integer division, truncating towards zero
+func (a Vec2[T]) Div(d Vec2[T]) Vec2[T] {
+	return Vec2[T]{a[0] / d[0], a[1] / d[1]}
 }
 
 func (v Vec2[T]) Parts() (T, T) {
@@ -36,6 +53,60 @@ func (a Vec2[T]) Dot(b Vec2[T]) T {
 	return a[0]*b[0] + a[1]*b[1]
 }
 
+// z component of the 3D cross product, i.e. the signed area of the parallelogram a, b
+func (a Vec2[T]) Cross(b Vec2[T]) T {
+	return a[0]*b[1] - a[1]*b[0]
+}
+
 func (a Vec2[T]) IsOrthogonal(b Vec2[T]) bool {
 	return a.Dot(b) == 0
 }
+
+func (a Vec2[T]) Manhattan(b Vec2[T]) T {
+	d := a.Sub(b)
+	return absInt(d[0]) + absInt(d[1])
+}
+
+func (a Vec2[T]) Chebyshev(b Vec2[T]) T {
+	d := a.Sub(b)
+	return max(absInt(d[0]), absInt(d[1]))
+}
+
+// rotations treat the vector as (row, col) like Grid does, so "clockwise" is as seen
+// on screen, e.g. up {-1, 0} rotates clockwise to right {0, 1}
+func (v Vec2[T]) Rotate90CW() Vec2[T] {
+	return Vec2[T]{v[1], -v[0]}
+}
+
+func (v Vec2[T]) Rotate90CCW() Vec2[T] {
+	return Vec2[T]{-v[1], v[0]}
+}
+
+// divide out the gcd of the components, giving the smallest integer step in the same direction
+func (v Vec2[T]) Reduce() Vec2[T] {
+	g := GCD(v[0], v[1])
+	if g == 0 {
+		return v
+	}
+	return Vec2[T]{v[0] / g, v[1] / g}
+}
+
+// component-wise sign, each component is -1, 0 or 1
+func (v Vec2[T]) Sign() Vec2[T] {
+	return Vec2[T]{T(cmp.Compare(v[0], 0)), T(cmp.Compare(v[1], 0))}
+}
+
+// component-wise minimum
+func (a Vec2[T]) Min(b Vec2[T]) Vec2[T] {
+	return Vec2[T]{min(a[0], b[0]), min(a[1], b[1])}
+}
+
+// component-wise maximum
+func (a Vec2[T]) Max(b Vec2[T]) Vec2[T] {
+	return Vec2[T]{max(a[0], b[0]), max(a[1], b[1])}
+}
+
+// lexicographic comparison, e.g. slices.SortFunc(vecs, Vec2[int].Cmp)
+func (a Vec2[T]) Cmp(b Vec2[T]) int {
+	return cmp.Or(cmp.Compare(a[0], b[0]), cmp.Compare(a[1], b[1]))
+}
@@ -0,0 +1,105 @@
+package util
+
+import (
+	"cmp"
+	"strings"
+)
+
+type Vec3[T Integer] [3]T
+
+// parse a Vec3[int] from string in format "x,y,z"
+func NewVec3Int(str string) Vec3[int] {
+	parts := strings.Split(str, ",")
+	return Vec3[int]{MustAtoi(parts[0]), MustAtoi(parts[1]), MustAtoi(parts[2])}
+}
+
+// parse a Vec3[int64] from string in format "x,y,z"
+func NewVec3Int64(str string) Vec3[int64] {
+	parts := strings.Split(str, ",")
+	return Vec3[int64]{MustAtoiInt64(parts[0]), MustAtoiInt64(parts[1]), MustAtoiInt64(parts[2])}
+}
+
+func (a Vec3[T]) Add(b Vec3[T]) Vec3[T] {
+	return Vec3[T]{a[0] + b[0], a[1] + b[1], a[2] + b[2]}
+}
+
+func (v Vec3[T]) Mul(c T) Vec3[T] {
+	return Vec3[T]{c * v[0], c * v[1], c * v[2]}
+}
+
+func (a Vec3[T]) Sub(b Vec3[T]) Vec3[T] {
+	return a.Add(b.Neg())
+}
+
+func (v Vec3[T]) Neg() Vec3[T] {
+	return Vec3[T]{-v[0], -v[1], -v[2]}
+}
+
+// component-wise modulo, always non-negative
+func (a Vec3[T]) Mod(m Vec3[T]) Vec3[T] {
+	return Vec3[T]{Mod(a[0], m[0]), Mod(a[1], m[1]), Mod(a[2], m[2])}
+}
+
+// component-wise integer division, truncating towards zero
+func (a Vec3[T]) Div(d Vec3[T]) Vec3[T] {
+	return Vec3[T]{a[0] / d[0], a[1] / d[1], a[2] / d[2]}
+}
+
+func (v Vec3[T]) Parts() (T, T, T) {
+	return v[0], v[1], v[2]
+}
+
+func (a Vec3[T]) Dot(b Vec3[T]) T {
+	return a[0]*b[0] + a[1]*b[1] + a[2]*b[2]
+}
+
+func (a Vec3[T]) Cross(b Vec3[T]) Vec3[T] {
+	return Vec3[T]{
+		a[1]*b[2] - a[2]*b[1],
+		a[2]*b[0] - a[0]*b[2],
+		a[0]*b[1] - a[1]*b[0],
+	}
+}
+
+func (a Vec3[T]) IsOrthogonal(b Vec3[T]) bool {
+	return a.Dot(b) == 0
+}
+
+func (a Vec3[T]) Manhattan(b Vec3[T]) T {
+	d := a.Sub(b)
+	return absInt(d[0]) + absInt(d[1]) + absInt(d[2])
+}
+
+func (a Vec3[T]) Chebyshev(b Vec3[T]) T {
+	d := a.Sub(b)
+	return max(absInt(d[0]), absInt(d[1]), absInt(d[2]))
+}
+
+// divide out the gcd of the components, giving the smallest integer step in the same direction
+func (v Vec3[T]) Reduce() Vec3[T] {
+	g := GCD(GCD(v[0], v[1]), v[2])
+	if g == 0 {
+		return v
+	}
+	return Vec3[T]{v[0] / g, v[1] / g, v[2] / g}
+}
+
+// component-wise sign, each component is -1, 0 or 1
+func (v Vec3[T]) Sign() Vec3[T] {
+	return Vec3[T]{T(cmp.Compare(v[0], 0)), T(cmp.Compare(v[1], 0)), T(cmp.Compare(v[2], 0))}
+}
+
+// component-wise minimum
+func (a Vec3[T]) Min(b Vec3[T]) Vec3[T] {
+	return Vec3[T]{min(a[0], b[0]), min(a[1], b[1]), min(a[2], b[2])}
+}
+
+// component-wise maximum
+func (a Vec3[T]) Max(b Vec3[T]) Vec3[T] {
+	return Vec3[T]{max(a[0], b[0]), max(a[1], b[1]), max(a[2], b[2])}
+}
+
+// lexicographic comparison, e.g. slices.SortFunc(vecs, Vec3[int].Cmp)
+func (a Vec3[T]) Cmp(b Vec3[T]) int {
+	return cmp.Or(cmp.Compare(a[0], b[0]), cmp.Compare(a[1], b[1]), cmp.Compare(a[2], b[2]))
+}